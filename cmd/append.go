@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var appendHeading string
+var appendAtTop bool
+var appendCmd = &cobra.Command{
+	Use:   "append",
+	Short: "Appends text to a note, optionally under a heading",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		noteContent, rawContent, err := actions.ReadContent(content, contentFile, os.Stdin)
+		if err != nil {
+			log.Fatal(err)
+		}
+		params := actions.AppendParams{
+			NoteName:   args[0],
+			Heading:    appendHeading,
+			Content:    noteContent,
+			RawContent: rawContent,
+			AtTop:      appendAtTop,
+		}
+		err = actions.AppendToNote(&vault, params)
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	appendCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	appendCmd.Flags().StringVarP(&content, "content", "c", "", "text to add to note, or - to read it from stdin")
+	appendCmd.Flags().StringVar(&contentFile, "content-file", "", "file to read the text to add to note from")
+	appendCmd.Flags().StringVar(&appendHeading, "under", "", "heading to add the text under, e.g. \"## Log\" (created if missing)")
+	appendCmd.Flags().BoolVar(&appendAtTop, "top", false, "add the text at the top of the section instead of the end")
+	appendCmd.MarkFlagsOneRequired("content", "content-file")
+	appendCmd.MarkFlagsMutuallyExclusive("content", "content-file")
+	rootCmd.AddCommand(appendCmd)
+}
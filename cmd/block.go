@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var blockCmd = &cobra.Command{
+	Use:   "block",
+	Short: "Works with block references (^block-id)",
+}
+
+var blockIDCmd = &cobra.Command{
+	Use:   "id <note> <line>",
+	Short: "Adds a block ID to a paragraph or list item and prints the link to it",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		line, err := strconv.Atoi(args[1])
+		if err != nil {
+			log.Fatalf("Invalid line number: %s", args[1])
+		}
+		vault := obsidian.Vault{Name: vaultName}
+		link, err := actions.CreateBlockID(&vault, actions.BlockIDParams{NoteName: args[0], Line: line})
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(link)
+	},
+}
+
+func init() {
+	blockIDCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	blockCmd.AddCommand(blockIDCmd)
+	rootCmd.AddCommand(blockCmd)
+}
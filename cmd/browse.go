@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/browse"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/gdamore/tcell/v2"
+	"github.com/spf13/cobra"
+)
+
+var browseCmd = &cobra.Command{
+	Use:     "browse",
+	Aliases: []string{"b"},
+	Short:   "Browse the vault in a full-screen view with folders, notes, a preview and backlinks",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		note := obsidian.Note{}
+		uri := obsidian.Uri{}
+		browser, err := browse.New(&vault, &note, &uri)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		screen, err := tcell.NewScreen()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = screen.Init()
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer screen.Fini()
+		err = browser.Run(screen)
+		if err != nil {
+			// log.Fatal exits without running deferred calls.
+			screen.Fini()
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	browseCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	rootCmd.AddCommand(browseCmd)
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var commandNote string
+var commandCmd = &cobra.Command{
+	Use:   "command <id>",
+	Short: "Runs an Obsidian command by its ID, needs the Advanced URI plugin",
+	Long: `Runs an Obsidian command by its ID, such as editor:toggle-bold or
+workspace:split-vertical. The IDs can be copied from the Advanced URI plugin
+with its "copy URI for command" command. With --note the note is opened first
+and the command runs on it.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		uri := obsidian.Uri{}
+		params := actions.CommandParams{CommandID: args[0], NoteName: commandNote}
+		err := actions.RunCommand(&vault, &uri, params)
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	commandCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	commandCmd.Flags().StringVarP(&commandNote, "note", "n", "", "note to open and run the command on")
+	rootCmd.AddCommand(commandCmd)
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var copyLinkCallback string
+var copyLinkCmd = &cobra.Command{
+	Use:   "copy-link",
+	Short: "Has Obsidian copy a link to the note open in it",
+	Long: `Has Obsidian copy a Markdown link to the note open in it to the clipboard,
+as it does for Hook. With --callback Obsidian opens the given x-callback-url
+with the link instead.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		uri := obsidian.Uri{}
+		err := actions.CopyActiveNoteLink(&vault, &uri, copyLinkCallback)
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	copyLinkCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	copyLinkCmd.Flags().StringVar(&copyLinkCallback, "callback", "", "x-callback-url to call with the link")
+	rootCmd.AddCommand(copyLinkCmd)
+}
@@ -5,11 +5,16 @@ import (
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"github.com/spf13/cobra"
 	"log"
+	"os"
 )
 
 var shouldAppend bool
 var shouldOverwrite bool
 var content string
+var contentFile string
+var templateName string
+var pickTemplate bool
+var templateVariables []string
 var createNoteCmd = &cobra.Command{
 	Use:     "create",
 	Aliases: []string{"c"},
@@ -23,13 +28,34 @@ var createNoteCmd = &cobra.Command{
 		if err != nil {
 			log.Fatalf("Failed to parse --editor flag: %v", err)
 		}
+		if pickTemplate {
+			fuzzyFinder := obsidian.FuzzyFinder{}
+			templateName, err = actions.PickTemplate(&vault, &fuzzyFinder)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+		variables, err := parseVariables(templateVariables)
+		if err != nil {
+			log.Fatal(err)
+		}
+		noteContent, rawContent, err := actions.ReadContent(content, contentFile, os.Stdin)
+		if err != nil {
+			log.Fatal(err)
+		}
 		params := actions.CreateParams{
 			NoteName:        noteName,
-			Content:         content,
+			Content:         noteContent,
+			RawContent:      rawContent,
 			ShouldAppend:    shouldAppend,
 			ShouldOverwrite: shouldOverwrite,
 			ShouldOpen:      shouldOpen,
 			UseEditor:       useEditor,
+			Template:        templateName,
+			Variables:       variables,
+		}
+		if content != actions.StdinContent && isTerminal(os.Stdin) {
+			params.Prompt = promptForValue
 		}
 		err = actions.CreateNote(&vault, &uri, params)
 		if err != nil {
@@ -41,10 +67,16 @@ var createNoteCmd = &cobra.Command{
 func init() {
 	createNoteCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
 	createNoteCmd.Flags().BoolVarP(&shouldOpen, "open", "", false, "open created note")
-	createNoteCmd.Flags().StringVarP(&content, "content", "c", "", "text to add to note")
+	createNoteCmd.Flags().StringVarP(&content, "content", "c", "", "text to add to note, or - to read it from stdin")
+	createNoteCmd.Flags().StringVar(&contentFile, "content-file", "", "file to read the text to add to note from")
 	createNoteCmd.Flags().BoolVarP(&shouldAppend, "append", "a", false, "append to note")
 	createNoteCmd.Flags().BoolVarP(&shouldOverwrite, "overwrite", "o", false, "overwrite note")
 	createNoteCmd.Flags().BoolP("editor", "e", false, "open in editor instead of Obsidian (requires --open flag)")
+	createNoteCmd.Flags().StringVarP(&templateName, "template", "t", "", "template from the templates folder to create the note from")
+	createNoteCmd.Flags().BoolVar(&pickTemplate, "pick-template", false, "pick the template to create the note from")
+	createNoteCmd.Flags().StringArrayVar(&templateVariables, "var", nil, "template variable as key=value (repeatable)")
 	createNoteCmd.MarkFlagsMutuallyExclusive("append", "overwrite")
+	createNoteCmd.MarkFlagsMutuallyExclusive("template", "pick-template")
+	createNoteCmd.MarkFlagsMutuallyExclusive("content", "content-file")
 	rootCmd.AddCommand(createNoteCmd)
 }
@@ -1,13 +1,23 @@
 package cmd
 
 import (
+	"fmt"
 	"log"
+	"os"
+	"time"
 
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"github.com/spf13/cobra"
 )
 
+var dailyDate string
+var dailyYesterday bool
+var dailyTomorrow bool
+var dailyNoOpen bool
+var dailyAppend string
+var dailyHeading string
+var dailyAtTop bool
 var DailyCmd = &cobra.Command{
 	Use:     "daily",
 	Aliases: []string{"d"},
@@ -16,14 +26,90 @@ var DailyCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		vault := obsidian.Vault{Name: vaultName}
 		uri := obsidian.Uri{}
-		err := actions.DailyNote(&vault, &uri)
+		useEditor, err := cmd.Flags().GetBool("editor")
+		if err != nil {
+			log.Fatalf("Failed to parse --editor flag: %v", err)
+		}
+		date, err := dailyNoteDate()
+		if err != nil {
+			log.Fatal(err)
+		}
+		appendContent, rawContent, err := actions.ReadContent(dailyAppend, "", os.Stdin)
+		if err != nil {
+			log.Fatal(err)
+		}
+		params := actions.DailyParams{
+			Date:          date,
+			ShouldOpen:    !dailyNoOpen && dailyAppend == "",
+			UseEditor:     useEditor,
+			AppendContent: appendContent,
+			RawContent:    rawContent,
+			Heading:       dailyHeading,
+			AtTop:         dailyAtTop,
+		}
+		err = actions.DailyNote(&vault, &uri, params)
 		if err != nil {
 			log.Fatal(err)
 		}
 	},
 }
 
+var rolloverDate string
+var rolloverHeading string
+var rolloverMove bool
+var rolloverMarkMigrated bool
+var dailyRolloverCmd = &cobra.Command{
+	Use:   "rollover",
+	Short: "Carries unfinished tasks from the previous daily note over to today's",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		date, err := obsidian.ParseDateArgument(rolloverDate, time.Now())
+		if err != nil {
+			log.Fatal(err)
+		}
+		params := actions.RolloverParams{
+			Date:         date,
+			Heading:      rolloverHeading,
+			Move:         rolloverMove,
+			MarkMigrated: rolloverMarkMigrated,
+		}
+		result, err := actions.DailyRollover(&vault, params)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Rolled over %d tasks from %s to %s\n", result.Count, result.FromNote, result.ToNote)
+	},
+}
+
+func dailyNoteDate() (time.Time, error) {
+	switch {
+	case dailyYesterday:
+		return obsidian.ParseDateArgument("yesterday", time.Now())
+	case dailyTomorrow:
+		return obsidian.ParseDateArgument("tomorrow", time.Now())
+	}
+	return obsidian.ParseDateArgument(dailyDate, time.Now())
+}
+
 func init() {
 	DailyCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name (not required if default is set)")
+	DailyCmd.Flags().StringVar(&dailyDate, "date", "", "date of the daily note (YYYY-MM-DD, today, yesterday or tomorrow)")
+	DailyCmd.Flags().BoolVar(&dailyYesterday, "yesterday", false, "use yesterday's daily note")
+	DailyCmd.Flags().BoolVar(&dailyTomorrow, "tomorrow", false, "use tomorrow's daily note")
+	DailyCmd.Flags().BoolVar(&dailyNoOpen, "no-open", false, "only create the note, do not open it")
+	DailyCmd.Flags().BoolP("editor", "e", false, "open in editor instead of Obsidian")
+	DailyCmd.Flags().StringVarP(&dailyAppend, "append", "a", "", "text to add to the daily note instead of opening it, or - to read it from stdin")
+	DailyCmd.Flags().StringVar(&dailyHeading, "under", "", "heading to add the text under, e.g. \"## Log\" (created if missing)")
+	DailyCmd.Flags().BoolVar(&dailyAtTop, "top", false, "add the text at the top of the section instead of the end")
+	DailyCmd.MarkFlagsMutuallyExclusive("date", "yesterday", "tomorrow")
+	DailyCmd.MarkFlagsMutuallyExclusive("no-open", "editor")
+	dailyRolloverCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name (not required if default is set)")
+	dailyRolloverCmd.Flags().StringVar(&rolloverDate, "date", "", "date of the daily note to roll tasks over to (YYYY-MM-DD, today, yesterday or tomorrow)")
+	dailyRolloverCmd.Flags().StringVar(&rolloverHeading, "under", "## Tasks", "heading to add the tasks under (created if missing)")
+	dailyRolloverCmd.Flags().BoolVar(&rolloverMove, "move", false, "remove the tasks from the previous daily note")
+	dailyRolloverCmd.Flags().BoolVar(&rolloverMarkMigrated, "mark-migrated", false, "mark the tasks as migrated ([>]) in the previous daily note")
+	dailyRolloverCmd.MarkFlagsMutuallyExclusive("move", "mark-migrated")
+	DailyCmd.AddCommand(dailyRolloverCmd)
 	rootCmd.AddCommand(DailyCmd)
 }
@@ -1,26 +1,41 @@
 package cmd
 
 import (
+	"fmt"
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"log"
+	"os"
 
 	"github.com/spf13/cobra"
 )
 
 var deleteCmd = &cobra.Command{
-	Use:     "delete",
+	Use:     "delete [note]",
 	Aliases: []string{"d"},
-	Short:   "Delete note in vault",
-	Args:    cobra.ExactArgs(1),
+	Short:   "Delete note in vault, or notes picked with fuzzy search",
+	Args:    cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		vault := obsidian.Vault{Name: vaultName}
 		note := obsidian.Note{}
-		notePath := args[0]
-		params := actions.DeleteParams{NotePath: notePath}
-		err := actions.DeleteNote(&vault, &note, params)
-		if err != nil {
-			log.Fatal(err)
+		notePaths := args
+		if len(args) == 0 {
+			fuzzyFinder := obsidian.FuzzyFinder{}
+			picked, err := actions.PickNotes(&vault, &note, &fuzzyFinder)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if len(picked) > 1 && isTerminal(os.Stdin) && !confirm(fmt.Sprintf("Delete %d notes?", len(picked))) {
+				return
+			}
+			notePaths = picked
+		}
+		for _, notePath := range notePaths {
+			params := actions.DeleteParams{NotePath: notePath}
+			err := actions.DeleteNote(&vault, &note, params)
+			if err != nil {
+				log.Fatal(err)
+			}
 		}
 	},
 }
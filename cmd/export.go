@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var exportOutput string
+var exportICSParams actions.ExportICSParams
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports vault data to other formats",
+}
+
+var exportICSCmd = &cobra.Command{
+	Use:   "ics",
+	Short: "Exports dated tasks and notes as an iCalendar file",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		uri := obsidian.Uri{}
+		if exportOutput == "" || exportOutput == "-" {
+			err := actions.ExportICS(&vault, &uri, exportICSParams, os.Stdout)
+			if err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+
+		// Write next to the target and rename, so a calendar polling the file
+		// never reads it half written.
+		file, err := os.CreateTemp(filepath.Dir(exportOutput), ".obsidian-cli-*.ics")
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer os.Remove(file.Name())
+		err = actions.ExportICS(&vault, &uri, exportICSParams, file)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Chmod(file.Name(), 0644)
+		}
+		if err == nil {
+			err = os.Rename(file.Name(), exportOutput)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	exportICSCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	exportICSCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "file to write the calendar to (default stdout)")
+	exportICSCmd.Flags().BoolVar(&exportICSParams.IncludeDone, "include-done", false, "also export done and cancelled tasks")
+	exportICSCmd.Flags().BoolVar(&exportICSParams.DailyNotes, "daily-notes", false, "also export daily notes as all-day events")
+	exportCmd.AddCommand(exportICSCmd)
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var frontmatterCmd = &cobra.Command{
+	Use:   "frontmatter",
+	Short: "Change note frontmatter through Obsidian, needs the Advanced URI plugin",
+}
+
+var frontmatterSetCmd = &cobra.Command{
+	Use:   "set <note> <key> <value>",
+	Short: "Sets a frontmatter property of a note",
+	Long: `Sets a frontmatter property of a note, with Obsidian making the change so
+that open editors and plugins see it. Empty and false values cannot be sent
+in a URI, so edit the note for those.`,
+	Args: cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		uri := obsidian.Uri{}
+		params := actions.FrontmatterParams{NoteName: args[0], Key: args[1], Value: args[2]}
+		err := actions.WriteFrontmatterInObsidian(&vault, &uri, params)
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	frontmatterSetCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	frontmatterCmd.AddCommand(frontmatterSetCmd)
+	rootCmd.AddCommand(frontmatterCmd)
+}
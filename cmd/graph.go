@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/graph"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var graphFilter actions.GraphFilter
+var graphFormat string
+var graphOutput string
+var graphTop int
+var graphDirected bool
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Works with the graph of links between notes",
+}
+
+var graphExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports the link graph as DOT, GraphML, JSON or Mermaid",
+	Example: "  obsidian-cli graph export --format dot | dot -Tsvg > vault.svg\n" +
+		"  obsidian-cli graph export --format mermaid --note \"Project\" --depth 2",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		params := actions.GraphExportParams{GraphFilter: graphFilter, Format: graphFormat}
+
+		var w io.Writer = os.Stdout
+		if graphOutput != "" {
+			file, err := os.Create(graphOutput)
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer file.Close()
+			w = file
+		}
+		err := actions.ExportGraph(&vault, params, w)
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+var graphStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Shows the most linked and central notes, clusters and dead ends",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		params := actions.GraphStatsParams{GraphFilter: graphFilter, Top: graphTop}
+		stats, err := actions.GetGraphStats(&vault, params)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Notes: %d\nLinks: %d\nClusters: %d\n", stats.Notes, stats.Links, len(stats.Components))
+		fmt.Println("\nMost linked:")
+		for _, score := range stats.MostLinked {
+			fmt.Printf("  %4d  %s\n", int(score.Value), obsidian.RemoveMdSuffix(score.ID))
+		}
+		fmt.Println("\nPageRank:")
+		for _, score := range stats.PageRank {
+			fmt.Printf("  %.4f  %s\n", score.Value, obsidian.RemoveMdSuffix(score.ID))
+		}
+		fmt.Println("\nClusters:")
+		for i, component := range stats.Components {
+			if graphTop > 0 && i == graphTop {
+				fmt.Printf("  ... and %d more\n", len(stats.Components)-i)
+				break
+			}
+			fmt.Printf("  %4d  around %s\n", len(component), obsidian.RemoveMdSuffix(component[0]))
+		}
+		fmt.Println("\nDead ends:")
+		for _, id := range stats.DeadEnds {
+			fmt.Printf("  %s\n", obsidian.RemoveMdSuffix(id))
+		}
+	},
+}
+
+var graphPathCmd = &cobra.Command{
+	Use:   "path <from-note> <to-note>",
+	Short: "Shows the shortest chain of links between two notes",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		params := actions.GraphPathParams{From: args[0], To: args[1], Directed: graphDirected}
+		path, err := actions.FindGraphPath(&vault, params)
+		if err != nil {
+			log.Fatal(err)
+		}
+		names := make([]string, len(path))
+		for i, id := range path {
+			names[i] = obsidian.RemoveMdSuffix(id)
+		}
+		fmt.Println(strings.Join(names, " -> "))
+	},
+}
+
+func init() {
+	graphExportCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	graphExportCmd.Flags().StringVarP(&graphFormat, "format", "f", graph.FormatJSON, "output format: dot, graphml, json or mermaid")
+	graphExportCmd.Flags().StringVarP(&graphOutput, "output", "o", "", "write to a file instead of stdout")
+	graphExportCmd.Flags().StringVar(&graphFilter.Folder, "folder", "", "only include notes in this folder")
+	graphExportCmd.Flags().StringVar(&graphFilter.Tag, "tag", "", "only include notes with this tag")
+	graphExportCmd.Flags().StringVar(&graphFilter.Note, "note", "", "only include notes near this note")
+	graphExportCmd.Flags().IntVar(&graphFilter.Depth, "depth", 1, "how many links away from --note to include")
+	graphExportCmd.Flags().BoolVar(&graphFilter.Attachments, "attachments", false, "include linked attachments such as images and PDFs")
+	graphStatsCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	graphStatsCmd.Flags().IntVarP(&graphTop, "top", "n", 10, "how many notes and clusters to list, 0 for all")
+	graphStatsCmd.Flags().StringVar(&graphFilter.Folder, "folder", "", "only include notes in this folder")
+	graphStatsCmd.Flags().StringVar(&graphFilter.Tag, "tag", "", "only include notes with this tag")
+	graphPathCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	graphPathCmd.Flags().BoolVar(&graphDirected, "directed", false, "only follow links forwards, not backlinks")
+	graphCmd.AddCommand(graphExportCmd)
+	graphCmd.AddCommand(graphStatsCmd)
+	graphCmd.AddCommand(graphPathCmd)
+	rootCmd.AddCommand(graphCmd)
+}
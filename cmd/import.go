@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var importDate string
+var importICSParams actions.ImportICSParams
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Creates notes from other formats",
+}
+
+var importICSCmd = &cobra.Command{
+	Use:   "ics <file>",
+	Short: "Creates a note for each meeting of the day in an iCalendar file",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		date, err := obsidian.ParseDateArgument(importDate, time.Now())
+		if err != nil {
+			log.Fatal(err)
+		}
+		importICSParams.Date = date
+
+		var calendarFile io.Reader = os.Stdin
+		if args[0] != actions.StdinContent {
+			file, err := os.Open(args[0])
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer file.Close()
+			calendarFile = file
+		}
+
+		result, err := actions.ImportICS(&vault, calendarFile, importICSParams)
+		for _, notePath := range result.Created {
+			fmt.Println("Created meeting note: ", notePath)
+		}
+		for _, notePath := range result.Skipped {
+			fmt.Println("Skipped existing note: ", notePath)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	importICSCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	importICSCmd.Flags().StringVar(&importDate, "date", "", "day to create meeting notes for (YYYY-MM-DD, today, yesterday or tomorrow)")
+	importICSCmd.Flags().StringVar(&importICSParams.Folder, "folder", actions.DefaultMeetingsFolder, "folder to create the meeting notes in")
+	importICSCmd.Flags().StringVarP(&importICSParams.Template, "template", "t", "", "template from the templates folder to use for the notes")
+	importICSCmd.Flags().BoolVar(&importICSParams.LinkDaily, "link-daily", false, "list the meeting notes under \"## Meetings\" in the daily note")
+	importCmd.AddCommand(importICSCmd)
+	rootCmd.AddCommand(importCmd)
+}
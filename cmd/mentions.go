@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var mentionsLink bool
+var mentionsLinkAll bool
+
+var mentionsCmd = &cobra.Command{
+	Use:   "mentions <note>",
+	Short: "Finds unlinked mentions of a note, and links them with --link",
+	Example: "  obsidian-cli mentions \"Project Apollo\"\n" +
+		"  obsidian-cli mentions \"Project Apollo\" --link --yes",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		params := actions.MentionsParams{NoteName: args[0]}
+		if !mentionsLink {
+			mentions, err := actions.FindMentions(&vault, params)
+			if err != nil {
+				log.Fatal(err)
+			}
+			for _, mention := range mentions {
+				fmt.Println(formatMention(mention))
+			}
+			return
+		}
+
+		if !mentionsLinkAll {
+			if !isTerminal(os.Stdin) {
+				log.Fatal("Cannot ask which mentions to link without a terminal, pass --yes to link them all")
+			}
+			params.Confirm = confirmMention()
+		}
+		linked, err := actions.LinkMentions(&vault, params)
+		if err != nil {
+			log.Fatal(err)
+		}
+		notes := map[string]bool{}
+		for _, mention := range linked {
+			notes[mention.FilePath] = true
+		}
+		fmt.Printf("Linked %d mention(s) in %d note(s)\n", len(linked), len(notes))
+	},
+}
+
+func formatMention(mention obsidian.Mention) string {
+	return fmt.Sprintf("%s:%d: %s", filepath.ToSlash(mention.FilePath), mention.LineNumber, strings.TrimSpace(mention.Line))
+}
+
+// confirmMention asks about each mention in turn. Answering all links the
+// rest without asking and quit skips them.
+func confirmMention() func(obsidian.Mention) bool {
+	answered := ""
+	return func(mention obsidian.Mention) bool {
+		switch answered {
+		case "a":
+			return true
+		case "q":
+			return false
+		}
+		for {
+			fmt.Fprintf(os.Stderr, "%s\nLink %q? [y]es/[n]o/[a]ll/[q]uit: ", formatMention(mention), mention.Text)
+			answer, err := stdinReader.ReadString('\n')
+			if err != nil && answer == "" {
+				answered = "q"
+				return false
+			}
+			switch strings.ToLower(strings.TrimSpace(answer)) {
+			case "y", "yes":
+				return true
+			case "n", "no", "":
+				return false
+			case "a", "all":
+				answered = "a"
+				return true
+			case "q", "quit":
+				answered = "q"
+				return false
+			}
+		}
+	}
+}
+
+func init() {
+	mentionsCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	mentionsCmd.Flags().BoolVar(&mentionsLink, "link", false, "turn the mentions into links, asking about each one")
+	mentionsCmd.Flags().BoolVarP(&mentionsLinkAll, "yes", "y", false, "with --link, link every mention without asking")
+	rootCmd.AddCommand(mentionsCmd)
+}
@@ -1,25 +1,59 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"log"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 )
 
 var shouldOpen bool
+var moveToFolder string
 var moveCmd = &cobra.Command{
 	Use:     "move",
 	Aliases: []string{"m"},
 	Short:   "Move or rename note in vault and updated corresponding links",
-	Args:    cobra.ExactArgs(2),
+	Example: "  obsidian-cli move \"Inbox/idea\" \"Projects/idea\"\n" +
+		"  obsidian-cli move --to-folder Archive \"old note\" \"older note\"\n" +
+		"  obsidian-cli move --to-folder Archive",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if moveToFolder != "" {
+			return nil
+		}
+		if len(args) != 2 {
+			return errors.New("expected the current and the new note name, or --to-folder")
+		}
+		return nil
+	},
 	Run: func(cmd *cobra.Command, args []string) {
-		currentName := args[0]
-		newName := args[1]
 		vault := obsidian.Vault{Name: vaultName}
 		note := obsidian.Note{}
 		uri := obsidian.Uri{}
+		if moveToFolder != "" {
+			noteNames := args
+			if len(args) == 0 {
+				fuzzyFinder := obsidian.FuzzyFinder{}
+				picked, err := actions.PickNotes(&vault, &note, &fuzzyFinder)
+				if err != nil {
+					log.Fatal(err)
+				}
+				noteNames = picked
+			}
+			params := actions.MoveToFolderParams{NoteNames: noteNames, Folder: moveToFolder}
+			moved, err := actions.MoveNotesToFolder(&vault, &note, &uri, params)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("Moved %d note(s) to %s\n", len(moved), filepath.ToSlash(moveToFolder))
+			return
+		}
+
+		currentName := args[0]
+		newName := args[1]
 		useEditor, err := cmd.Flags().GetBool("editor")
 		if err != nil {
 			log.Fatalf("Failed to parse --editor flag: %v", err)
@@ -42,5 +76,6 @@ func init() {
 	moveCmd.Flags().BoolVarP(&shouldOpen, "open", "o", false, "open new note")
 	moveCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
 	moveCmd.Flags().BoolP("editor", "e", false, "open in editor instead of Obsidian (requires --open flag)")
+	moveCmd.Flags().StringVar(&moveToFolder, "to-folder", "", "move the notes given, or picked with fuzzy search, into this folder")
 	rootCmd.AddCommand(moveCmd)
 }
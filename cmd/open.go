@@ -9,6 +9,9 @@ import (
 )
 
 var vaultName string
+var openHeading string
+var openLine int
+var openNewPane bool
 var OpenVaultCmd = &cobra.Command{
 	Use:     "open",
 	Aliases: []string{"o"},
@@ -18,7 +21,7 @@ var OpenVaultCmd = &cobra.Command{
 		vault := obsidian.Vault{Name: vaultName}
 		uri := obsidian.Uri{}
 		noteName := args[0]
-		params := actions.OpenParams{NoteName: noteName}
+		params := actions.OpenParams{NoteName: noteName, Heading: openHeading, Line: openLine, NewPane: openNewPane}
 		err := actions.OpenNote(&vault, &uri, params)
 		if err != nil {
 			log.Fatal(err)
@@ -28,5 +31,8 @@ var OpenVaultCmd = &cobra.Command{
 
 func init() {
 	OpenVaultCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name (not required if default is set)")
+	OpenVaultCmd.Flags().StringVar(&openHeading, "heading", "", "heading to scroll to in the note")
+	OpenVaultCmd.Flags().IntVarP(&openLine, "line", "l", 0, "line to put the cursor on, needs the Advanced URI plugin")
+	OpenVaultCmd.Flags().BoolVar(&openNewPane, "new-pane", false, "open the note in a new tab, needs the Advanced URI plugin")
 	rootCmd.AddCommand(OpenVaultCmd)
 }
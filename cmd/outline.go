@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var outlineCmd = &cobra.Command{
+	Use:   "outline <note>",
+	Short: "Prints the headings of a note with their line numbers",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		note := obsidian.Note{}
+		headings, err := actions.Outline(&vault, &note, actions.OutlineParams{NoteName: args[0]})
+		if err != nil {
+			log.Fatal(err)
+		}
+		printOutline(headings)
+	},
+}
+
+// printOutline indents each heading under the headings above it, so that a
+// note starting at ## is not indented needlessly.
+func printOutline(headings []obsidian.Heading) {
+	var parents []int
+	for _, heading := range headings {
+		for len(parents) > 0 && parents[len(parents)-1] >= heading.Level {
+			parents = parents[:len(parents)-1]
+		}
+		fmt.Printf("%5d  %s%s %s\n", heading.LineNumber, strings.Repeat("  ", len(parents)), strings.Repeat("#", heading.Level), heading.Text)
+		parents = append(parents, heading.Level)
+	}
+}
+
+func init() {
+	outlineCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	rootCmd.AddCommand(outlineCmd)
+}
@@ -2,35 +2,66 @@ package cmd
 
 import (
 	"fmt"
-	"github.com/Yakitrak/obsidian-cli/pkg/actions"
-	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"log"
+	"os"
 
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/Yakitrak/obsidian-cli/pkg/render"
 	"github.com/spf13/cobra"
 )
 
 var shouldRenderMarkdown bool
+var printResolveEmbeds bool
+var printEmbedDepth int
+var printSkipAttachments bool
 var printCmd = &cobra.Command{
-	Use:     "print",
+	Use:     "print [note[#heading]]",
 	Aliases: []string{"p"},
-	Short:   "Print contents of note",
-	Args:    cobra.ExactArgs(1),
+	Short:   "Print contents of note, or of one of its sections, or of notes picked with fuzzy search",
+	Args:    cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		noteName := args[0]
 		vault := obsidian.Vault{Name: vaultName}
 		note := obsidian.Note{}
-		params := actions.PrintParams{
-			NoteName: noteName,
+		noteNames := args
+		if len(args) == 0 {
+			fuzzyFinder := obsidian.FuzzyFinder{}
+			picked, err := actions.PickNotes(&vault, &note, &fuzzyFinder)
+			if err != nil {
+				log.Fatal(err)
+			}
+			noteNames = picked
 		}
-		contents, err := actions.PrintNote(&vault, &note, params)
-		if err != nil {
-			log.Fatal(err)
+		for i, noteName := range noteNames {
+			params := actions.PrintParams{
+				NoteName:        noteName,
+				ResolveEmbeds:   printResolveEmbeds,
+				EmbedDepth:      printEmbedDepth,
+				SkipAttachments: printSkipAttachments,
+			}
+			contents, err := actions.PrintNote(&vault, &note, params)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if shouldRenderMarkdown {
+				// Styles would only get in the way of commands reading the output.
+				if width, ok := render.TerminalWidth(os.Stdout); ok {
+					contents = render.Markdown(contents, width)
+				}
+			}
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Println(contents)
 		}
-		fmt.Println(contents)
 	},
 }
 
 func init() {
 	printCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	printCmd.Flags().BoolVarP(&shouldRenderMarkdown, "render", "r", false, "format the note for reading in the terminal")
+	printCmd.Flags().BoolVar(&printResolveEmbeds, "resolve-embeds", false, "replace ![[embeds]] with the content they embed")
+	printCmd.Flags().IntVar(&printEmbedDepth, "embed-depth", obsidian.DefaultEmbedDepth, "how many levels of nested embeds to resolve")
+	printCmd.Flags().BoolVar(&printSkipAttachments, "skip-attachments", false, "leave out embedded images and other files instead of printing their path")
 	rootCmd.AddCommand(printCmd)
 }
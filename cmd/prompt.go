@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// isTerminal reports whether f is attached to an interactive terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// promptForValue asks for a value on stderr and reads the answer from stdin.
+func promptForValue(name string) (string, error) {
+	fmt.Fprintf(os.Stderr, "%s: ", name)
+	answer, err := stdinReader.ReadString('\n')
+	if err != nil && answer == "" {
+		return "", err
+	}
+	return strings.TrimRight(answer, "\r\n"), nil
+}
+
+// confirm asks a yes or no question on stderr and reports whether the answer
+// was yes.
+func confirm(question string) bool {
+	answer, err := promptForValue(question + " [y/N]")
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// parseVariables turns repeated key=value flags into a map.
+func parseVariables(pairs []string) (map[string]string, error) {
+	variables := map[string]string{}
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid variable %q, expected key=value", pair)
+		}
+		variables[strings.TrimSpace(key)] = value
+	}
+	return variables, nil
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var renameHeadingCmd = &cobra.Command{
+	Use:   "rename-heading <note> <old-heading> <new-heading>",
+	Short: "Renames a heading in a note and updates the links to it",
+	Args:  cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		params := actions.RenameHeadingParams{
+			NoteName:   args[0],
+			OldHeading: args[1],
+			NewHeading: args[2],
+		}
+		err := actions.RenameHeading(&vault, params)
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	renameHeadingCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	rootCmd.AddCommand(renameHeadingCmd)
+}
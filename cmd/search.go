@@ -1,13 +1,18 @@
 package cmd
 
 import (
+	"fmt"
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"log"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 )
 
+var searchMulti bool
+var searchPrint bool
+var searchPrint0 bool
 var searchCmd = &cobra.Command{
 	Use:     "search",
 	Aliases: []string{"s"},
@@ -22,15 +27,41 @@ var searchCmd = &cobra.Command{
 		if err != nil {
 			log.Fatalf("failed to retrieve 'editor' flag: %v", err)
 		}
-		err = actions.SearchNotes(&vault, &note, &uri, &fuzzyFinder, useEditor)
+		if !searchMulti && !searchPrint && !searchPrint0 {
+			err = actions.SearchNotes(&vault, &note, &uri, &fuzzyFinder, useEditor)
+			if err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+
+		notes, err := actions.PickNotes(&vault, &note, &fuzzyFinder)
 		if err != nil {
 			log.Fatal(err)
 		}
+		switch {
+		case searchPrint0:
+			for _, notePath := range notes {
+				fmt.Print(filepath.ToSlash(notePath) + "\x00")
+			}
+		case searchPrint:
+			for _, notePath := range notes {
+				fmt.Println(filepath.ToSlash(notePath))
+			}
+		default:
+			err = actions.OpenNotes(&vault, &uri, notes, useEditor)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
 	},
 }
 
 func init() {
 	searchCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
 	searchCmd.Flags().BoolP("editor", "e", false, "open in editor instead of Obsidian")
+	searchCmd.Flags().BoolVarP(&searchMulti, "multi", "m", false, "mark several notes with tab and open them all")
+	searchCmd.Flags().BoolVar(&searchPrint, "print", false, "print the paths of the marked notes instead of opening them")
+	searchCmd.Flags().BoolVar(&searchPrint0, "print0", false, "like --print, but end each path with a NUL character for xargs -0")
 	rootCmd.AddCommand(searchCmd)
 }
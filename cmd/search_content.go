@@ -21,6 +21,17 @@ var searchContentCmd = &cobra.Command{
 		fuzzyFinder := obsidian.FuzzyFinder{}
 
 		searchTerm := args[0]
+		inObsidian, err := cmd.Flags().GetBool("in-obsidian")
+		if err != nil {
+			log.Fatalf("Failed to parse 'in-obsidian' flag: %v", err)
+		}
+		if inObsidian {
+			err = actions.SearchInObsidian(&vault, &uri, searchTerm)
+			if err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
 		useEditor, err := cmd.Flags().GetBool("editor")
 		if err != nil {
 			log.Fatalf("Failed to parse 'editor' flag: %v", err)
@@ -35,5 +46,6 @@ var searchContentCmd = &cobra.Command{
 func init() {
 	searchContentCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
 	searchContentCmd.Flags().BoolP("editor", "e", false, "open in editor instead of Obsidian")
+	searchContentCmd.Flags().Bool("in-obsidian", false, "show the results in the search pane of Obsidian instead")
 	rootCmd.AddCommand(searchContentCmd)
 }
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var editorCommand string
+var editorWaitFlags []string
+var setEditorCmd = &cobra.Command{
+	Use:   "set-editor [editor]",
+	Short: "Sets the editor used to open notes and how it is run",
+	Long: `Sets the editor used by --editor in place of $VISUAL and $EDITOR. It can
+carry arguments, quoted as in a shell, e.g. "emacsclient -t". An empty editor
+goes back to $VISUAL and $EDITOR.
+
+--command sets a template used instead of the built-in support for common
+editors. {editor} is replaced with the editor, {file} with the note path, and
+{line} and {column} with the cursor position, e.g.
+
+  obsidian-cli set-editor --command "{editor} +{line} {file}"
+
+An empty command goes back to the built-in support.
+
+--wait-flag sets the flag a GUI editor needs to block until the note is
+closed, by executable name, e.g. --wait-flag gvim=-f. An empty flag, e.g.
+--wait-flag code=, stops one being passed.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 && !cmd.Flags().Changed("command") && len(editorWaitFlags) == 0 {
+			log.Fatal("Please give an editor, --command or --wait-flag")
+		}
+
+		if len(args) == 1 {
+			err := obsidian.SetEditor(args[0])
+			if err != nil {
+				log.Fatal(err)
+			}
+			if args[0] == "" {
+				fmt.Println("Editor cleared, using $VISUAL or $EDITOR")
+			} else {
+				fmt.Println("Editor set to: ", args[0])
+			}
+		}
+
+		if cmd.Flags().Changed("command") {
+			err := obsidian.SetEditorCommand(editorCommand)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if editorCommand == "" {
+				fmt.Println("Editor command cleared")
+			} else {
+				fmt.Println("Editor command set to: ", editorCommand)
+			}
+		}
+
+		for _, waitFlag := range editorWaitFlags {
+			name, flag, ok := strings.Cut(waitFlag, "=")
+			if !ok || name == "" {
+				log.Fatal("Please give wait flags as editor=flag, e.g. code=--wait")
+			}
+			err := obsidian.SetEditorWaitFlag(name, flag)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("Wait flag for %s set to: %q\n", name, flag)
+		}
+	},
+}
+
+func init() {
+	setEditorCmd.Flags().StringVar(&editorCommand, "command", "", "command template with {editor}, {file}, {line} and {column}")
+	setEditorCmd.Flags().StringArrayVar(&editorWaitFlags, "wait-flag", nil, "flag an editor needs to block until the note is closed, as editor=flag")
+	rootCmd.AddCommand(setEditorCmd)
+}
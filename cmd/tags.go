@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var tagsTree bool
+var tagsSortByCount bool
+var tagsAddNotes []string
+
+var tagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "Lists, adds, renames and merges tags across the vault",
+}
+
+var tagsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "Lists tags with the number of times each is used",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		counts, err := actions.ListTags(&vault)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if tagsTree {
+			printTagTree(obsidian.TagTree(counts), 0)
+			return
+		}
+		if tagsSortByCount {
+			sort.SliceStable(counts, func(i, j int) bool {
+				return counts[i].Count > counts[j].Count
+			})
+		}
+		for _, count := range counts {
+			fmt.Printf("#%s\t%d\n", count.Name, count.Count)
+		}
+	},
+}
+
+var tagsRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Renames a tag and the tags nested under it in every note",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		changed, err := actions.RenameTags(&vault, args[:1], args[1])
+		if err != nil {
+			log.Fatal(err)
+		}
+		printChangedNotes(changed)
+	},
+}
+
+var tagsMergeCmd = &cobra.Command{
+	Use:     "merge <tag>... into <tag>",
+	Short:   "Merges several tags into one in every note",
+	Example: "  obsidian-cli tags merge todo to-do into task",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 3 || args[len(args)-2] != "into" {
+			return errors.New("expected tags to merge followed by \"into\" and the target tag")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		changed, err := actions.RenameTags(&vault, args[:len(args)-2], args[len(args)-1])
+		if err != nil {
+			log.Fatal(err)
+		}
+		printChangedNotes(changed)
+	},
+}
+
+var tagsAddCmd = &cobra.Command{
+	Use:   "add <tag>...",
+	Short: "Adds tags to notes, or to notes picked with fuzzy search",
+	Example: "  obsidian-cli tags add project/apollo --note \"Kickoff\" --note \"Budget\"\n" +
+		"  obsidian-cli tags add inbox/triaged",
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		noteNames := tagsAddNotes
+		if len(noteNames) == 0 {
+			note := obsidian.Note{}
+			fuzzyFinder := obsidian.FuzzyFinder{}
+			picked, err := actions.PickNotes(&vault, &note, &fuzzyFinder)
+			if err != nil {
+				log.Fatal(err)
+			}
+			noteNames = picked
+		}
+		changed, err := actions.AddTags(&vault, noteNames, args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		printChangedNotes(changed)
+	},
+}
+
+func printTagTree(nodes []*obsidian.TagNode, depth int) {
+	for _, node := range nodes {
+		fmt.Printf("%s#%s\t%d\n", strings.Repeat("  ", depth), node.Name, node.Total)
+		printTagTree(node.Children, depth+1)
+	}
+}
+
+func printChangedNotes(changed []string) {
+	for _, notePath := range changed {
+		fmt.Println(filepath.ToSlash(notePath))
+	}
+	fmt.Printf("Updated %d note(s)\n", len(changed))
+}
+
+func init() {
+	tagsListCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	tagsListCmd.Flags().BoolVar(&tagsTree, "tree", false, "show nested tags under their parents, with totals")
+	tagsListCmd.Flags().BoolVar(&tagsSortByCount, "sort-count", false, "sort by count instead of name")
+	tagsRenameCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	tagsMergeCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	tagsAddCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	tagsAddCmd.Flags().StringArrayVarP(&tagsAddNotes, "note", "n", nil, "note to tag, can be repeated; picks notes with fuzzy search if not given")
+	tagsCmd.AddCommand(tagsListCmd)
+	tagsCmd.AddCommand(tagsAddCmd)
+	tagsCmd.AddCommand(tagsRenameCmd)
+	tagsCmd.AddCommand(tagsMergeCmd)
+	rootCmd.AddCommand(tagsCmd)
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var taskFilter obsidian.TaskFilter
+var tasksDue string
+var tasksDueBefore string
+var tasksDueAfter string
+
+var tasksCmd = &cobra.Command{
+	Use:   "tasks",
+	Short: "Lists and updates checklist tasks across the vault",
+}
+
+var tasksListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "Lists tasks in the vault, optionally filtered",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		var err error
+		taskFilter.DueOn, err = optionalDate(tasksDue)
+		if err != nil {
+			log.Fatal(err)
+		}
+		taskFilter.DueBefore, err = optionalDate(tasksDueBefore)
+		if err != nil {
+			log.Fatal(err)
+		}
+		taskFilter.DueAfter, err = optionalDate(tasksDueAfter)
+		if err != nil {
+			log.Fatal(err)
+		}
+		tasks, err := actions.ListTasks(&vault, taskFilter)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, task := range tasks {
+			printTask(task)
+		}
+	},
+}
+
+var tasksDoneCmd = &cobra.Command{
+	Use:   "done <id>",
+	Short: "Checks off a task, adding today's done date",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		task, err := actions.CompleteTask(&vault, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		printTask(task)
+	},
+}
+
+var tasksToggleCmd = &cobra.Command{
+	Use:   "toggle <id>",
+	Short: "Checks off an open task or reopens a done one",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		task, err := actions.ToggleTask(&vault, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		printTask(task)
+	},
+}
+
+func printTask(task obsidian.Task) {
+	fmt.Printf("%s  %s  (%s:%d)\n", task.ID, task.Line, filepath.ToSlash(task.FilePath), task.LineNumber)
+}
+
+// optionalDate parses a date flag, leaving it unset when empty.
+func optionalDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return obsidian.ParseDateArgument(value, time.Now())
+}
+
+func init() {
+	tasksListCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	tasksListCmd.Flags().StringVar(&taskFilter.Status, "status", obsidian.TaskStatusTodo, "task status: todo, done, cancelled or all")
+	tasksListCmd.Flags().StringVar(&taskFilter.Note, "note", "", "only list tasks in this note")
+	tasksListCmd.Flags().StringVar(&taskFilter.Folder, "folder", "", "only list tasks in notes under this folder")
+	tasksListCmd.Flags().StringVar(&taskFilter.Tag, "tag", "", "only list tasks with this tag (nested tags included)")
+	tasksListCmd.Flags().StringVar(&tasksDue, "due", "", "only list tasks due on this date (YYYY-MM-DD, today, yesterday or tomorrow)")
+	tasksListCmd.Flags().StringVar(&tasksDueBefore, "due-before", "", "only list tasks due before this date")
+	tasksListCmd.Flags().StringVar(&tasksDueAfter, "due-after", "", "only list tasks due after this date")
+	tasksDoneCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	tasksToggleCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	tasksCmd.AddCommand(tasksListCmd)
+	tasksCmd.AddCommand(tasksDoneCmd)
+	tasksCmd.AddCommand(tasksToggleCmd)
+	rootCmd.AddCommand(tasksCmd)
+}
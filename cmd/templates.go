@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Manage note templates from the templates folder",
+}
+
+var templatesListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "Lists templates in the templates folder",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		templates, err := actions.ListTemplates(&vault)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, template := range templates {
+			fmt.Println(template)
+		}
+	},
+}
+
+func init() {
+	templatesListCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	templatesCmd.AddCommand(templatesListCmd)
+	rootCmd.AddCommand(templatesCmd)
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/spf13/cobra"
+)
+
+var workspaceCmd = &cobra.Command{
+	Use:   "workspace",
+	Short: "Manage Obsidian workspaces, needs the Advanced URI plugin",
+}
+
+var workspaceLoadCmd = &cobra.Command{
+	Use:   "load <name>",
+	Short: "Switches Obsidian to a saved workspace",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vault := obsidian.Vault{Name: vaultName}
+		uri := obsidian.Uri{}
+		err := actions.LoadWorkspace(&vault, &uri, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	workspaceLoadCmd.Flags().StringVarP(&vaultName, "vault", "v", "", "vault name")
+	workspaceCmd.AddCommand(workspaceLoadCmd)
+	rootCmd.AddCommand(workspaceCmd)
+}
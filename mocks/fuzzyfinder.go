@@ -1,8 +1,9 @@
 package mocks
 
 type MockFuzzyFinder struct {
-	SelectedIndex int
-	FindErr       error
+	SelectedIndex   int
+	SelectedIndexes []int
+	FindErr         error
 }
 
 func (f *MockFuzzyFinder) Find(slice interface{}, itemFunc func(i int) string, opts ...interface{}) (int, error) {
@@ -11,3 +12,10 @@ func (f *MockFuzzyFinder) Find(slice interface{}, itemFunc func(i int) string, o
 	}
 	return f.SelectedIndex, nil
 }
+
+func (f *MockFuzzyFinder) FindMulti(slice interface{}, itemFunc func(i int) string, opts ...interface{}) ([]int, error) {
+	if f.FindErr != nil {
+		return nil, f.FindErr
+	}
+	return f.SelectedIndexes, nil
+}
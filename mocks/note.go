@@ -8,6 +8,7 @@ type MockNoteManager struct {
 	UpdateLinksError error
 	GetContentsError error
 	NoMatches        bool
+	Contents         string
 }
 
 func (m *MockNoteManager) Delete(string) error {
@@ -23,6 +24,9 @@ func (m *MockNoteManager) UpdateLinks(string, string, string) error {
 }
 
 func (m *MockNoteManager) GetContents(string, string) (string, error) {
+	if m.Contents != "" {
+		return m.Contents, m.GetContentsError
+	}
 	return "example contents", m.GetContentsError
 }
 
@@ -3,9 +3,13 @@ package mocks
 type MockUriManager struct {
 	ConstructedURI string
 	ExecuteErr     error
+	BaseUri        string
+	Params         map[string]string
 }
 
 func (m *MockUriManager) Construct(base string, params map[string]string) string {
+	m.BaseUri = base
+	m.Params = params
 	return m.ConstructedURI
 }
 
@@ -4,6 +4,7 @@ type MockVaultOperator struct {
 	DefaultNameErr error
 	PathError      error
 	Name           string
+	VaultPath      string
 }
 
 func (m *MockVaultOperator) DefaultName() (string, error) {
@@ -24,5 +25,8 @@ func (m *MockVaultOperator) Path() (string, error) {
 	if m.PathError != nil {
 		return "", m.PathError
 	}
+	if m.VaultPath != "" {
+		return m.VaultPath, nil
+	}
 	return "path", nil
 }
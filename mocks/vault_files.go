@@ -0,0 +1,24 @@
+package mocks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// CreateVaultFiles creates the files, by vault relative path, in a new
+// temporary vault and returns its path.
+func CreateVaultFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	vaultPath := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(vaultPath, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return vaultPath
+}
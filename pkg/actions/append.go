@@ -0,0 +1,44 @@
+package actions
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+type AppendParams struct {
+	NoteName   string
+	Heading    string
+	Content    string
+	RawContent bool
+	AtTop      bool
+}
+
+func AppendToNote(vault obsidian.VaultManager, params AppendParams) error {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return err
+	}
+
+	// Appending writes, so a name such as ../x must not reach past the vault.
+	newNotePath := filepath.Join(vaultPath, obsidian.AddMdSuffix(params.NoteName))
+	relPath, err := filepath.Rel(vaultPath, newNotePath)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return errors.New(obsidian.NoteOutsideVaultError)
+	}
+
+	notePath, err := obsidian.ResolveNotePath(vaultPath, params.NoteName)
+	if err != nil {
+		// Quick capture into a note that does not exist yet creates it.
+		notePath = newNotePath
+	}
+
+	return obsidian.InsertIntoNote(notePath, params.Heading, noteContent(params.Content, params.RawContent), params.AtTop)
+}
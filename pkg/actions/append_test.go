@@ -0,0 +1,110 @@
+package actions_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendToNote(t *testing.T) {
+	t.Run("Successful append under heading", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		notePath := filepath.Join(vault.VaultPath, "folder", "note.md")
+		err := os.MkdirAll(filepath.Dir(notePath), 0755)
+		assert.NoError(t, err)
+		err = os.WriteFile(notePath, []byte("## Log\n- a\n\n## Other\n"), 0644)
+		assert.NoError(t, err)
+		// Act
+		err = actions.AppendToNote(&vault, actions.AppendParams{
+			NoteName: "note",
+			Heading:  "## Log",
+			Content:  "- b\\n- c",
+		})
+		// Assert
+		assert.NoError(t, err)
+		content, _ := os.ReadFile(notePath)
+		assert.Equal(t, "## Log\n- a\n- b\n- c\n\n## Other\n", string(content))
+	})
+
+	t.Run("Raw content keeps backslashes", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		// Act
+		err := actions.AppendToNote(&vault, actions.AppendParams{
+			NoteName:   "note",
+			Content:    "$\\nabla$\n",
+			RawContent: true,
+		})
+		// Assert
+		assert.NoError(t, err)
+		content, _ := os.ReadFile(filepath.Join(vault.VaultPath, "note.md"))
+		assert.Equal(t, "$\\nabla$\n", string(content))
+	})
+
+	t.Run("Creates note that does not exist", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		// Act
+		err := actions.AppendToNote(&vault, actions.AppendParams{
+			NoteName: "inbox/new",
+			Content:  "captured",
+		})
+		// Assert
+		assert.NoError(t, err)
+		content, _ := os.ReadFile(filepath.Join(vault.VaultPath, "inbox", "new.md"))
+		assert.Equal(t, "captured\n", string(content))
+	})
+
+	t.Run("Note outside the vault", func(t *testing.T) {
+		// Arrange
+		parent := t.TempDir()
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: filepath.Join(parent, "vault")}
+		assert.NoError(t, os.Mkdir(vault.VaultPath, 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(parent, "existing.md"), []byte("kept\n"), 0644))
+		// Act
+		newErr := actions.AppendToNote(&vault, actions.AppendParams{
+			NoteName: "../outside/new",
+			Content:  "captured",
+		})
+		existingErr := actions.AppendToNote(&vault, actions.AppendParams{
+			NoteName: "../existing",
+			Content:  "captured",
+		})
+		// Assert
+		assert.EqualError(t, newErr, obsidian.NoteOutsideVaultError)
+		assert.EqualError(t, existingErr, obsidian.NoteOutsideVaultError)
+		_, err := os.Stat(filepath.Join(parent, "outside"))
+		assert.True(t, os.IsNotExist(err))
+		content, _ := os.ReadFile(filepath.Join(parent, "existing.md"))
+		assert.Equal(t, "kept\n", string(content))
+	})
+
+	t.Run("vault.DefaultName returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{
+			DefaultNameErr: errors.New("Failed to get vault name"),
+		}
+		// Act
+		err := actions.AppendToNote(&vault, actions.AppendParams{NoteName: "note"})
+		// Assert
+		assert.Equal(t, vault.DefaultNameErr, err)
+	})
+
+	t.Run("vault.Path returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{
+			PathError: errors.New("Failed to get vault path"),
+		}
+		// Act
+		err := actions.AppendToNote(&vault, actions.AppendParams{NoteName: "note"})
+		// Assert
+		assert.Equal(t, vault.PathError, err)
+	})
+}
@@ -0,0 +1,54 @@
+package actions
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+type BlockIDParams struct {
+	NoteName string
+	Line     int
+}
+
+// CreateBlockID adds a block ID to the paragraph or list item at the given
+// line of a note and returns the [[note#^id]] link to it.
+func CreateBlockID(vault obsidian.VaultManager, params BlockIDParams) (string, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return "", err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return "", err
+	}
+
+	notePath, err := obsidian.ResolveNotePath(vaultPath, params.NoteName)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(notePath)
+	if err != nil {
+		return "", errors.New(obsidian.VaultReadError)
+	}
+	content, err := os.ReadFile(notePath)
+	if err != nil {
+		return "", errors.New(obsidian.VaultReadError)
+	}
+
+	updated, id, err := obsidian.AddBlockID(string(content), params.Line)
+	if err != nil {
+		return "", err
+	}
+	if updated != string(content) {
+		err = os.WriteFile(notePath, []byte(updated), info.Mode())
+		if err != nil {
+			return "", errors.New(obsidian.VaultWriteError)
+		}
+	}
+
+	return obsidian.BlockLink(obsidian.RemoveMdSuffix(filepath.Base(notePath)), id), nil
+}
@@ -0,0 +1,37 @@
+package actions_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateBlockID(t *testing.T) {
+	t.Run("Adds an ID and returns the link", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		notePath := filepath.Join(vault.VaultPath, "Decisions.md")
+		assert.NoError(t, os.WriteFile(notePath, []byte("# Decisions\n- Use Go\n"), 0644))
+		// Act
+		link, err := actions.CreateBlockID(&vault, actions.BlockIDParams{NoteName: "Decisions", Line: 2})
+		// Assert
+		assert.NoError(t, err)
+		content, _ := os.ReadFile(notePath)
+		id := string(content)[len("# Decisions\n- Use Go ^") : len(content)-1]
+		assert.Equal(t, "[[Decisions#^"+id+"]]", link)
+		block, err := obsidian.ExtractBlock(string(content), id)
+		assert.NoError(t, err)
+		assert.Equal(t, "- Use Go\n", block)
+	})
+
+	t.Run("Missing note", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		_, err := actions.CreateBlockID(&vault, actions.BlockIDParams{NoteName: "Missing", Line: 1})
+		assert.EqualError(t, err, obsidian.NoteDoesNotExistError)
+	})
+}
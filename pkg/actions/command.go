@@ -0,0 +1,44 @@
+package actions
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+type CommandParams struct {
+	CommandID string
+	NoteName  string
+}
+
+// RunCommand runs an Obsidian command by its ID, such as
+// "editor:toggle-bold", through the Advanced URI plugin. When a note is
+// given it is opened first and the command runs on it.
+func RunCommand(vault obsidian.VaultManager, uri obsidian.UriManager, params CommandParams) error {
+	commandID := strings.TrimSpace(params.CommandID)
+	if commandID == "" {
+		return errors.New(obsidian.InvalidCommandError)
+	}
+
+	vaultName, err := vault.DefaultName()
+	if err != nil {
+		return err
+	}
+
+	uriParams := map[string]string{
+		"vault":     vaultName,
+		"commandid": commandID,
+	}
+	if params.NoteName != "" {
+		vaultPath, err := vault.Path()
+		if err != nil {
+			return err
+		}
+		file := obsidian.ResolveNoteName(vaultPath, params.NoteName)
+		uriParams["filepath"] = filepath.ToSlash(obsidian.AddMdSuffix(file))
+	}
+
+	return uri.Execute(uri.Construct(ObsAdvancedUrl, uriParams))
+}
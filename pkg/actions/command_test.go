@@ -0,0 +1,49 @@
+package actions_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunCommand(t *testing.T) {
+	t.Run("Runs a command", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		uri := mocks.MockUriManager{}
+		// Act
+		err := actions.RunCommand(&vault, &uri, actions.CommandParams{CommandID: "editor:toggle-bold"})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, actions.ObsAdvancedUrl, uri.BaseUri)
+		assert.Equal(t, map[string]string{"vault": "myVault", "commandid": "editor:toggle-bold"}, uri.Params)
+	})
+
+	t.Run("Runs a command on a note", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		uri := mocks.MockUriManager{}
+		// Act
+		err := actions.RunCommand(&vault, &uri, actions.CommandParams{CommandID: "editor:fold-all", NoteName: "note"})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "note.md", uri.Params["filepath"])
+	})
+
+	t.Run("Empty command ID", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		err := actions.RunCommand(&vault, &mocks.MockUriManager{}, actions.CommandParams{CommandID: " "})
+		assert.EqualError(t, err, obsidian.InvalidCommandError)
+	})
+
+	t.Run("Error in executing URI", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		uri := mocks.MockUriManager{ExecuteErr: errors.New(obsidian.ExecuteUriError)}
+		err := actions.RunCommand(&vault, &uri, actions.CommandParams{CommandID: "app:reload"})
+		assert.EqualError(t, err, obsidian.ExecuteUriError)
+	})
+}
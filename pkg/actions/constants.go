@@ -1,12 +1,23 @@
 package actions
 
 const (
-	obsBaseUrl   = "obsidian://"
-	openAction   = "open"
-	createAction = "new"
-	dailyAction  = "daily"
+	obsBaseUrl           = "obsidian://"
+	openAction           = "open"
+	createAction         = "new"
+	dailyAction          = "daily"
+	searchAction         = "search"
+	hookGetAddressAction = "hook-get-address"
+	advancedUriAction    = "adv-uri"
 
-	ObsOpenUrl   = obsBaseUrl + openAction
-	ObsCreateUrl = obsBaseUrl + createAction
-	OnsDailyUrl  = obsBaseUrl + dailyAction
+	ObsOpenUrl           = obsBaseUrl + openAction
+	ObsCreateUrl         = obsBaseUrl + createAction
+	OnsDailyUrl          = obsBaseUrl + dailyAction
+	ObsSearchUrl         = obsBaseUrl + searchAction
+	ObsHookGetAddressUrl = obsBaseUrl + hookGetAddressAction
+	// ObsAdvancedUrl is the URI of the Advanced URI community plugin, which
+	// has to be installed in the vault.
+	ObsAdvancedUrl = obsBaseUrl + advancedUriAction
+
+	// newPaneMode is the Advanced URI openmode opening a note in a new tab.
+	newPaneMode = "tab"
 )
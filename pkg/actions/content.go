@@ -0,0 +1,41 @@
+package actions
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// StdinContent is the content flag value that reads the content from stdin.
+const StdinContent = "-"
+
+// ReadContent resolves the note content given on the command line. Content
+// read from stdin ("-") or from contentFile is returned as is and reported as
+// raw, so callers skip NormalizeContent and keep backslashes intact.
+func ReadContent(content string, contentFile string, stdin io.Reader) (string, bool, error) {
+	if contentFile != "" {
+		data, err := os.ReadFile(contentFile)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read content file '%s': %w", contentFile, err)
+		}
+		return string(data), true, nil
+	}
+
+	if content == StdinContent {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read content from stdin: %w", err)
+		}
+		return string(data), true, nil
+	}
+
+	return content, false, nil
+}
+
+// noteContent returns content ready to be written to a note.
+func noteContent(content string, raw bool) string {
+	if raw {
+		return content
+	}
+	return NormalizeContent(content)
+}
@@ -0,0 +1,51 @@
+package actions_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadContent(t *testing.T) {
+	t.Run("Flag content is not raw", func(t *testing.T) {
+		// Act
+		content, raw, err := actions.ReadContent("line\\nline", "", strings.NewReader("ignored"))
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, raw)
+		assert.Equal(t, "line\\nline", content)
+	})
+
+	t.Run("Reads stdin when content is -", func(t *testing.T) {
+		// Act
+		content, raw, err := actions.ReadContent("-", "", strings.NewReader(`C:\new\table $\nabla$`))
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, raw)
+		assert.Equal(t, `C:\new\table $\nabla$`, content)
+	})
+
+	t.Run("Reads content file", func(t *testing.T) {
+		// Arrange
+		contentFile := filepath.Join(t.TempDir(), "report.md")
+		err := os.WriteFile(contentFile, []byte("# Report\n\\frac{a}{b}\n"), 0644)
+		assert.NoError(t, err)
+		// Act
+		content, raw, err := actions.ReadContent("", contentFile, strings.NewReader("ignored"))
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, raw)
+		assert.Equal(t, "# Report\n\\frac{a}{b}\n", content)
+	})
+
+	t.Run("Missing content file", func(t *testing.T) {
+		// Act
+		_, _, err := actions.ReadContent("", filepath.Join(t.TempDir(), "missing.md"), nil)
+		// Assert
+		assert.Error(t, err)
+	})
+}
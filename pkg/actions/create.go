@@ -14,8 +14,12 @@ type CreateParams struct {
 	ShouldAppend    bool
 	ShouldOverwrite bool
 	Content         string
+	RawContent      bool
 	ShouldOpen      bool
 	UseEditor       bool
+	Template        string
+	Variables       map[string]string
+	Prompt          func(name string) (string, error)
 }
 
 func CreateNote(vault obsidian.VaultManager, uri obsidian.UriManager, params CreateParams) error {
@@ -24,7 +28,23 @@ func CreateNote(vault obsidian.VaultManager, uri obsidian.UriManager, params Cre
 		return err
 	}
 
-	normalizedContent := NormalizeContent(params.Content)
+	normalizedContent := noteContent(params.Content, params.RawContent)
+
+	if params.Template != "" {
+		vaultPath, err := vault.Path()
+		if err != nil {
+			return err
+		}
+		rendered, err := renderNoteTemplate(vaultPath, params.Template, obsidian.TemplateData{
+			Title:     obsidian.RemoveMdSuffix(filepath.Base(params.NoteName)),
+			Date:      time.Now(),
+			Variables: params.Variables,
+		}, params.Prompt)
+		if err != nil {
+			return err
+		}
+		normalizedContent = joinContent(rendered, normalizedContent)
+	}
 
 	if params.UseEditor && params.ShouldOpen {
 		vaultPath, err := vault.Path()
@@ -69,6 +89,13 @@ func CreateNote(vault obsidian.VaultManager, uri obsidian.UriManager, params Cre
 	return nil
 }
 
+func joinContent(first string, second string) string {
+	if first == "" || second == "" || strings.HasSuffix(first, "\n") {
+		return first + second
+	}
+	return first + "\n" + second
+}
+
 func NormalizeContent(content string) string {
 	replacer := strings.NewReplacer(
 		"\\n", "\n",
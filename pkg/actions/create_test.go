@@ -7,6 +7,7 @@ import (
 
 	"github.com/Yakitrak/obsidian-cli/mocks"
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -94,6 +95,21 @@ func TestCreateNote(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("Raw content is passed through without unescaping", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		uri := mocks.MockUriManager{}
+		// Act
+		err := actions.CreateNote(&vault, &uri, actions.CreateParams{
+			NoteName:   "note.md",
+			Content:    `C:\new\table`,
+			RawContent: true,
+		})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, `C:\new\table`, uri.Params["content"])
+	})
+
 	t.Run("Create note with editor flag without open does not use editor", func(t *testing.T) {
 		// Arrange
 		vault := mocks.MockVaultOperator{Name: "myVault"}
@@ -111,6 +127,59 @@ func TestCreateNote(t *testing.T) {
 	})
 }
 
+func TestCreateNoteFromTemplate(t *testing.T) {
+	t.Run("Renders template with variables and prompts", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{
+			Name:      "myVault",
+			VaultPath: createTemplatesVault(t, map[string]string{"Meeting.md": "# {{title}}\nProject: {{project}}\nOwner: {{owner}}\n"}),
+		}
+		uri := mocks.MockUriManager{}
+		var prompted []string
+		// Act
+		err := actions.CreateNote(&vault, &uri, actions.CreateParams{
+			NoteName:  "Meetings/Kickoff",
+			Content:   "extra\\nline",
+			Template:  "meeting",
+			Variables: map[string]string{"project": "Apollo"},
+			Prompt: func(name string) (string, error) {
+				prompted = append(prompted, name)
+				return "Sam", nil
+			},
+		})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"owner"}, prompted)
+		assert.Equal(t, "# Kickoff\nProject: Apollo\nOwner: Sam\nextra\nline", uri.Params["content"])
+	})
+
+	t.Run("Template not found", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{VaultPath: createTemplatesVault(t, map[string]string{"Meeting.md": ""})}
+		// Act
+		err := actions.CreateNote(&vault, &mocks.MockUriManager{}, actions.CreateParams{
+			NoteName: "note",
+			Template: "retro",
+		})
+		// Assert
+		assert.Equal(t, obsidian.TemplateNotFoundError, err.Error())
+	})
+
+	t.Run("Prompt returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{VaultPath: createTemplatesVault(t, map[string]string{"Meeting.md": "{{owner}}"})}
+		promptErr := errors.New("no input")
+		// Act
+		err := actions.CreateNote(&vault, &mocks.MockUriManager{}, actions.CreateParams{
+			NoteName: "note",
+			Template: "Meeting",
+			Prompt:   func(string) (string, error) { return "", promptErr },
+		})
+		// Assert
+		assert.Equal(t, promptErr, err)
+	})
+}
+
 func TestNormalizeContent(t *testing.T) {
 	t.Run("Replaces escape sequences with actual characters", func(t *testing.T) {
 		// Arrange
@@ -1,17 +1,64 @@
 package actions
 
 import (
+	"path/filepath"
+	"time"
+
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 )
 
-func DailyNote(vault obsidian.VaultManager, uri obsidian.UriManager) error {
+type DailyParams struct {
+	Date          time.Time
+	ShouldOpen    bool
+	UseEditor     bool
+	AppendContent string
+	RawContent    bool
+	Heading       string
+	AtTop         bool
+}
+
+func DailyNote(vault obsidian.VaultManager, uri obsidian.UriManager, params DailyParams) error {
 	vaultName, err := vault.DefaultName()
 	if err != nil {
 		return err
 	}
 
-	obsidianUri := uri.Construct(OnsDailyUrl, map[string]string{
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return err
+	}
+
+	date := params.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	// Write the note ourselves rather than through the daily URI so this works
+	// when Obsidian is not running, e.g. from cron or over SSH.
+	notePath, err := obsidian.CreateDailyNote(vaultPath, date)
+	if err != nil {
+		return err
+	}
+
+	if params.AppendContent != "" {
+		content := noteContent(params.AppendContent, params.RawContent)
+		err = obsidian.InsertIntoNote(filepath.Join(vaultPath, notePath), params.Heading, content, params.AtTop)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !params.ShouldOpen {
+		return nil
+	}
+
+	if params.UseEditor {
+		return obsidian.OpenInEditor(filepath.Join(vaultPath, notePath))
+	}
+
+	obsidianUri := uri.Construct(ObsOpenUrl, map[string]string{
 		"vault": vaultName,
+		"file":  notePath,
 	})
 
 	err = uri.Execute(obsidianUri)
@@ -20,3 +67,61 @@ func DailyNote(vault obsidian.VaultManager, uri obsidian.UriManager) error {
 	}
 	return nil
 }
+
+type RolloverParams struct {
+	Date         time.Time
+	Heading      string
+	Move         bool
+	MarkMigrated bool
+}
+
+// RolloverResult reports where tasks were rolled over from and to.
+type RolloverResult struct {
+	FromNote string
+	ToNote   string
+	Count    int
+}
+
+// DailyRollover carries the open tasks of the most recent previous daily note
+// over to the daily note for params.Date, creating it if needed.
+func DailyRollover(vault obsidian.VaultManager, params RolloverParams) (RolloverResult, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return RolloverResult{}, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return RolloverResult{}, err
+	}
+
+	date := params.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	dailyNotesConfig, err := obsidian.ReadDailyNotesConfig(vaultPath)
+	if err != nil {
+		return RolloverResult{}, err
+	}
+
+	fromNote, err := obsidian.FindPreviousDailyNote(vaultPath, dailyNotesConfig, date)
+	if err != nil {
+		return RolloverResult{}, err
+	}
+
+	toNote, err := obsidian.CreateDailyNote(vaultPath, date)
+	if err != nil {
+		return RolloverResult{}, err
+	}
+
+	count, err := obsidian.RolloverTasks(vaultPath, fromNote, toNote, obsidian.RolloverOptions{
+		Heading:      params.Heading,
+		Move:         params.Move,
+		MarkMigrated: params.MarkMigrated,
+	})
+	if err != nil {
+		return RolloverResult{}, err
+	}
+	return RolloverResult{FromNote: fromNote, ToNote: toNote, Count: count}, nil
+}
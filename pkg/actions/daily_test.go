@@ -2,7 +2,10 @@ package actions_test
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/Yakitrak/obsidian-cli/mocks"
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
@@ -10,14 +13,46 @@ import (
 )
 
 func TestDailyNote(t *testing.T) {
+	date := time.Date(2024, time.March, 5, 9, 30, 0, 0, time.UTC)
+
 	t.Run("Successful creates / opens daily note", func(t *testing.T) {
 		// Arrange
-		vault := mocks.MockVaultOperator{Name: "myVault"}
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
 		uri := mocks.MockUriManager{}
 		// Act
-		err := actions.DailyNote(&vault, &uri)
+		err := actions.DailyNote(&vault, &uri, actions.DailyParams{Date: date, ShouldOpen: true})
 		// Assert
 		assert.Equal(t, err, nil)
+		_, err = os.Stat(filepath.Join(vault.VaultPath, "2024-03-05.md"))
+		assert.NoError(t, err, "Expected daily note to be written to disk")
+	})
+
+	t.Run("Creates daily note without opening it", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		uri := mocks.MockUriManager{ExecuteErr: errors.New("Obsidian is not running")}
+		// Act
+		err := actions.DailyNote(&vault, &uri, actions.DailyParams{Date: date, ShouldOpen: false})
+		// Assert
+		assert.NoError(t, err)
+		_, err = os.Stat(filepath.Join(vault.VaultPath, "2024-03-05.md"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("Appends to daily note under heading", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		// Act
+		err := actions.DailyNote(&vault, &mocks.MockUriManager{}, actions.DailyParams{
+			Date:          date,
+			AppendContent: "- 09:30 standup",
+			Heading:       "## Log",
+		})
+		// Assert
+		assert.NoError(t, err)
+		content, err := os.ReadFile(filepath.Join(vault.VaultPath, "2024-03-05.md"))
+		assert.NoError(t, err)
+		assert.Equal(t, "## Log\n- 09:30 standup\n", string(content))
 	})
 
 	t.Run("vault.DefaultName returns an error", func(t *testing.T) {
@@ -27,19 +62,55 @@ func TestDailyNote(t *testing.T) {
 			DefaultNameErr: vaultDefaultNameErr,
 		}
 		// Act
-		err := actions.DailyNote(vaultOp, &mocks.MockUriManager{})
+		err := actions.DailyNote(vaultOp, &mocks.MockUriManager{}, actions.DailyParams{Date: date})
 		// Assert
 		assert.Error(t, err, vaultDefaultNameErr)
 	})
 
+	t.Run("vault.Path returns an error", func(t *testing.T) {
+		// Arrange
+		vaultOp := &mocks.MockVaultOperator{
+			PathError: errors.New("Failed to get vault path"),
+		}
+		// Act
+		err := actions.DailyNote(vaultOp, &mocks.MockUriManager{}, actions.DailyParams{Date: date})
+		// Assert
+		assert.Equal(t, err, vaultOp.PathError)
+	})
+
 	t.Run("uri.Execute returns an error", func(t *testing.T) {
 		// Arrange
 		uri := mocks.MockUriManager{
 			ExecuteErr: errors.New("Failed to execute URI"),
 		}
+		vault := mocks.MockVaultOperator{VaultPath: t.TempDir()}
 		// Act
-		err := actions.DailyNote(&mocks.MockVaultOperator{}, &uri)
+		err := actions.DailyNote(&vault, &uri, actions.DailyParams{Date: date, ShouldOpen: true})
 		// Assert
 		assert.Equal(t, err, uri.ExecuteErr)
 	})
 }
+
+func TestDailyRollover(t *testing.T) {
+	date := time.Date(2024, time.March, 5, 9, 30, 0, 0, time.UTC)
+
+	t.Run("Rolls tasks over from the previous daily note", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		err := os.WriteFile(filepath.Join(vault.VaultPath, "2024-03-02.md"), []byte("- [ ] carry me\n- [x] done\n"), 0644)
+		assert.NoError(t, err)
+		// Act
+		result, err := actions.DailyRollover(&vault, actions.RolloverParams{Date: date, Heading: "## Tasks"})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, actions.RolloverResult{FromNote: "2024-03-02.md", ToNote: "2024-03-05.md", Count: 1}, result)
+		content, _ := os.ReadFile(filepath.Join(vault.VaultPath, "2024-03-05.md"))
+		assert.Equal(t, "## Tasks\n- [ ] carry me\n", string(content))
+	})
+
+	t.Run("vault.DefaultName returns an error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{DefaultNameErr: errors.New("Failed to get vault name")}
+		_, err := actions.DailyRollover(&vault, actions.RolloverParams{Date: date})
+		assert.Equal(t, vault.DefaultNameErr, err)
+	})
+}
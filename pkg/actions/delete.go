@@ -19,7 +19,7 @@ func DeleteNote(vault obsidian.VaultManager, note obsidian.NoteManager, params D
 	if err != nil {
 		return err
 	}
-	notePath := filepath.Join(vaultPath, params.NotePath)
+	notePath := filepath.Join(vaultPath, obsidian.ResolveNoteName(vaultPath, params.NotePath))
 
 	err = note.Delete(notePath)
 	if err != nil {
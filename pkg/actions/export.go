@@ -0,0 +1,197 @@
+package actions
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/ics"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+const icsProdID = "-//Yakitrak//obsidian-cli//EN"
+
+type ExportICSParams struct {
+	IncludeDone bool
+	DailyNotes  bool
+}
+
+var icsPriorities = map[string]int{
+	"highest": 1,
+	"high":    3,
+	"medium":  5,
+	"low":     7,
+	"lowest":  9,
+}
+
+// noteDateLayouts are the date and time formats accepted in the date, start
+// and end properties of a note.
+var noteDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+}
+
+// ExportICS writes an iCalendar file with a VTODO for every task with a date
+// and a VEVENT for every note with date, start or end properties. With
+// params.DailyNotes, daily notes become all-day events as well. UIDs are
+// derived from the note path and line so calendars can track changes.
+func ExportICS(vault obsidian.VaultManager, uri obsidian.UriManager, params ExportICSParams, w io.Writer) error {
+	vaultName, err := vault.DefaultName()
+	if err != nil {
+		return err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return err
+	}
+
+	var dailyNotesConfig obsidian.DailyNotesConfig
+	if params.DailyNotes {
+		dailyNotesConfig, err = obsidian.ReadDailyNotesConfig(vaultPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	calendar := ics.Calendar{ProdID: icsProdID, Name: vaultName}
+	err = obsidian.WalkNotes(vaultPath, func(relPath string, content []byte) error {
+		stamp := time.Now()
+		if info, err := os.Stat(filepath.Join(vaultPath, relPath)); err == nil {
+			stamp = info.ModTime()
+		}
+		noteName := obsidian.RemoveMdSuffix(filepath.Base(relPath))
+		noteUrl := uri.Construct(ObsOpenUrl, map[string]string{
+			"vault": vaultName,
+			"file":  obsidian.RemoveMdSuffix(filepath.ToSlash(relPath)),
+		})
+
+		// A note with invalid frontmatter can still have tasks to export.
+		properties, _, err := obsidian.ParseFrontmatter(string(content))
+		event, ok := noteEvent(properties)
+		if err == nil && ok {
+			event.UID = obsidian.StableID(relPath, 0) + "@obsidian-cli"
+			event.Stamp = stamp
+			event.Summary = noteName
+			if title := obsidian.FrontmatterString(properties, "title"); title != "" {
+				event.Summary = title
+			}
+			event.Location = obsidian.FrontmatterString(properties, "location")
+			event.URL = noteUrl
+			calendar.Events = append(calendar.Events, event)
+		} else if date, isDaily := dailyNoteDate(params.DailyNotes, dailyNotesConfig, relPath); isDaily {
+			calendar.Events = append(calendar.Events, ics.Event{
+				UID:     obsidian.StableID(relPath, 0) + "@obsidian-cli",
+				Stamp:   stamp,
+				Summary: noteName,
+				URL:     noteUrl,
+				Start:   date,
+				End:     date.AddDate(0, 0, 1),
+				AllDay:  true,
+			})
+		}
+
+		for _, task := range obsidian.ParseTasks(relPath, string(content)) {
+			if !task.IsOpen() && !params.IncludeDone {
+				continue
+			}
+			todo, ok := taskTodo(task)
+			if !ok {
+				continue
+			}
+			todo.UID = obsidian.StableID(relPath, task.LineNumber) + "@obsidian-cli"
+			todo.Stamp = stamp
+			todo.Description = "From " + filepath.ToSlash(relPath)
+			todo.URL = noteUrl
+			calendar.Todos = append(calendar.Todos, todo)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return calendar.Encode(w)
+}
+
+func dailyNoteDate(enabled bool, dailyNotesConfig obsidian.DailyNotesConfig, relPath string) (time.Time, bool) {
+	if !enabled {
+		return time.Time{}, false
+	}
+	return obsidian.DailyNoteDate(dailyNotesConfig, relPath)
+}
+
+// noteEvent builds an event from the date, start and end properties of a
+// note. Dates without a time give all-day events.
+func noteEvent(properties map[string]interface{}) (ics.Event, bool) {
+	startValue := obsidian.FrontmatterString(properties, "start")
+	if startValue == "" {
+		startValue = obsidian.FrontmatterString(properties, "date")
+	}
+	start, allDay, ok := parseNoteDate(startValue)
+	if !ok {
+		return ics.Event{}, false
+	}
+
+	event := ics.Event{Start: start, AllDay: allDay}
+	end, endAllDay, ok := parseNoteDate(obsidian.FrontmatterString(properties, "end"))
+	switch {
+	case ok && allDay && endAllDay:
+		// The end date of a note is inclusive, DTEND is not.
+		event.End = end.AddDate(0, 0, 1)
+	case ok && !allDay && !endAllDay && end.After(start):
+		event.End = end
+	case allDay:
+		event.End = start.AddDate(0, 0, 1)
+	default:
+		event.End = start.Add(time.Hour)
+	}
+	return event, true
+}
+
+func parseNoteDate(value string) (time.Time, bool, bool) {
+	if value == "" {
+		return time.Time{}, false, false
+	}
+	if date, err := time.ParseInLocation(obsidian.DateLayout, value, time.Local); err == nil {
+		return date, true, true
+	}
+	for _, layout := range noteDateLayouts {
+		if date, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return date, false, true
+		}
+	}
+	return time.Time{}, false, false
+}
+
+// taskTodo builds a to-do from a task with a due, scheduled or start date.
+func taskTodo(task obsidian.Task) (ics.Todo, bool) {
+	todo := ics.Todo{
+		Summary:  task.Description,
+		Start:    task.Start,
+		Due:      task.Due,
+		Priority: icsPriorities[task.Priority],
+		Status:   ics.StatusNeedsAction,
+	}
+	if todo.Start.IsZero() {
+		todo.Start = task.Scheduled
+	}
+	if todo.Due.IsZero() {
+		todo.Due = task.Scheduled
+	}
+	if todo.Start.IsZero() && todo.Due.IsZero() {
+		return ics.Todo{}, false
+	}
+
+	switch {
+	case task.IsDone():
+		todo.Status = ics.StatusCompleted
+		todo.Completed = task.Done
+	case task.IsCancelled():
+		todo.Status = ics.StatusCancelled
+	}
+	return todo, true
+}
@@ -0,0 +1,80 @@
+package actions_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportICS(t *testing.T) {
+	createExportVault := func(t *testing.T) mocks.MockVaultOperator {
+		notes := map[string]string{
+			"Offsite.md":          "---\ntitle: Team offsite\nstart: 2024-01-10\nend: 2024-01-11\n---\n",
+			"Standup.md":          "---\nstart: 2024-01-10T09:00\n---\n",
+			"Inbox.md":            "- [ ] Pay rent 📅 2024-02-01 ⏫\n- [ ] Undated\n- [x] Done 📅 2024-01-01 ✅ 2024-01-01\n",
+			"Daily/2024-01-09.md": "",
+		}
+		return mocks.MockVaultOperator{Name: "myVault", VaultPath: mocks.CreateVaultFiles(t, notes)}
+	}
+
+	t.Run("Exports dated tasks and notes", func(t *testing.T) {
+		// Arrange
+		vault := createExportVault(t)
+		uri := mocks.MockUriManager{}
+		var out bytes.Buffer
+		// Act
+		err := actions.ExportICS(&vault, &uri, actions.ExportICSParams{}, &out)
+		// Assert
+		assert.NoError(t, err)
+		calendar := out.String()
+		assert.Equal(t, 1, strings.Count(calendar, "BEGIN:VTODO"))
+		assert.Equal(t, 2, strings.Count(calendar, "BEGIN:VEVENT"))
+		assert.Contains(t, calendar, "SUMMARY:Pay rent\r\n")
+		assert.Contains(t, calendar, "DUE;VALUE=DATE:20240201\r\n")
+		assert.Contains(t, calendar, "PRIORITY:3\r\n")
+		assert.Contains(t, calendar, "SUMMARY:Team offsite\r\n")
+		assert.Contains(t, calendar, "DTEND;VALUE=DATE:20240112\r\n")
+		assert.NotContains(t, calendar, "SUMMARY:2024-01-09")
+	})
+
+	t.Run("UIDs are stable between exports", func(t *testing.T) {
+		// Arrange
+		vault := createExportVault(t)
+		uri := mocks.MockUriManager{}
+		var first, second bytes.Buffer
+		// Act
+		assert.NoError(t, actions.ExportICS(&vault, &uri, actions.ExportICSParams{}, &first))
+		assert.NoError(t, actions.ExportICS(&vault, &uri, actions.ExportICSParams{}, &second))
+		// Assert
+		assert.Equal(t, first.String(), second.String())
+	})
+
+	t.Run("Includes done tasks and daily notes when asked", func(t *testing.T) {
+		// Arrange
+		vault := createExportVault(t)
+		configPath := filepath.Join(vault.VaultPath, ".obsidian", "daily-notes.json")
+		assert.NoError(t, os.MkdirAll(filepath.Dir(configPath), 0755))
+		assert.NoError(t, os.WriteFile(configPath, []byte(`{"folder": "Daily"}`), 0644))
+		uri := mocks.MockUriManager{}
+		var out bytes.Buffer
+		// Act
+		err := actions.ExportICS(&vault, &uri, actions.ExportICSParams{IncludeDone: true, DailyNotes: true}, &out)
+		// Assert
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "STATUS:COMPLETED\r\n")
+		assert.Contains(t, out.String(), "SUMMARY:2024-01-09\r\n")
+	})
+
+	t.Run("vault.DefaultName returns an error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{DefaultNameErr: errors.New("Failed to get vault name")}
+		err := actions.ExportICS(&vault, &mocks.MockUriManager{}, actions.ExportICSParams{}, &bytes.Buffer{})
+		assert.Equal(t, vault.DefaultNameErr, err)
+	})
+}
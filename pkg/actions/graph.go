@@ -0,0 +1,274 @@
+package actions
+
+import (
+	"errors"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/graph"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+// GraphFilter narrows the link graph to the notes in a folder or with a tag,
+// and to the notes within Depth links of Note. Attachments linked from the
+// notes are left out unless Attachments is set.
+type GraphFilter struct {
+	Folder      string
+	Tag         string
+	Note        string
+	Depth       int
+	Attachments bool
+}
+
+type GraphExportParams struct {
+	GraphFilter
+	Format string
+}
+
+func ExportGraph(vault obsidian.VaultManager, params GraphExportParams, w io.Writer) error {
+	if !graph.ValidFormat(params.Format) {
+		return errors.New(graph.UnknownFormatError)
+	}
+
+	vaultName, err := vault.DefaultName()
+	if err != nil {
+		return err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return err
+	}
+
+	g, _, err := buildGraph(vaultName, vaultPath, params.GraphFilter)
+	if err != nil {
+		return err
+	}
+	return graph.Encode(w, g, params.Format)
+}
+
+// buildGraph reads the notes of the vault into a graph of the links between
+// them, with the tags and frontmatter properties of each note. The index
+// it used is returned for looking up notes in the graph.
+func buildGraph(vaultName string, vaultPath string, filter GraphFilter) (graph.Graph, obsidian.NoteIndex, error) {
+	index, err := obsidian.BuildNoteIndex(vaultPath)
+	if err != nil {
+		return graph.Graph{}, obsidian.NoteIndex{}, err
+	}
+
+	g := graph.Graph{Name: vaultName, Nodes: []graph.Node{}, Edges: []graph.Edge{}}
+	attachments := map[string]bool{}
+	edges := map[graph.Edge]bool{}
+	err = obsidian.WalkNotes(vaultPath, func(relPath string, content []byte) error {
+		id := path.Clean(strings.ReplaceAll(relPath, "\\", "/"))
+		g.Nodes = append(g.Nodes, noteNode(id, string(content)))
+
+		for _, target := range obsidian.NoteLinks(string(content)) {
+			file, ok := index.Resolve(id, target)
+			if !ok || file == id {
+				continue
+			}
+			if !strings.HasSuffix(file, ".md") {
+				if !filter.Attachments {
+					continue
+				}
+				if !attachments[file] {
+					attachments[file] = true
+					g.Nodes = append(g.Nodes, graph.Node{ID: file, Label: path.Base(file), Attachment: true})
+				}
+			}
+			edge := graph.Edge{Source: id, Target: file}
+			if !edges[edge] {
+				edges[edge] = true
+				g.Edges = append(g.Edges, edge)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return graph.Graph{}, obsidian.NoteIndex{}, err
+	}
+
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].ID < g.Nodes[j].ID })
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].Source != g.Edges[j].Source {
+			return g.Edges[i].Source < g.Edges[j].Source
+		}
+		return g.Edges[i].Target < g.Edges[j].Target
+	})
+	g, err = filterGraph(g, index, filter)
+	return g, index, err
+}
+
+func noteNode(id string, content string) graph.Node {
+	node := graph.Node{ID: id, Label: obsidian.RemoveMdSuffix(path.Base(id))}
+	seen := map[string]bool{}
+	for _, tag := range obsidian.NoteTags(content) {
+		if !seen[strings.ToLower(tag)] {
+			seen[strings.ToLower(tag)] = true
+			node.Tags = append(node.Tags, tag)
+		}
+	}
+	properties, _, err := obsidian.ParseFrontmatter(content)
+	if err == nil {
+		for key, value := range properties {
+			// Tags are already on the node.
+			if key == "tags" || key == "tag" {
+				continue
+			}
+			if node.Properties == nil {
+				node.Properties = map[string]interface{}{}
+			}
+			node.Properties[key] = value
+		}
+	}
+	return node
+}
+
+func filterGraph(g graph.Graph, index obsidian.NoteIndex, filter GraphFilter) (graph.Graph, error) {
+	center := ""
+	if filter.Note != "" {
+		var ok bool
+		center, ok = index.Resolve("", filter.Note)
+		if !ok {
+			return graph.Graph{}, errors.New(obsidian.NoteDoesNotExistError)
+		}
+	}
+
+	folder := strings.Trim(strings.ReplaceAll(filter.Folder, "\\", "/"), "/")
+	keptNotes := map[string]bool{}
+	for _, node := range g.Nodes {
+		if node.Attachment {
+			continue
+		}
+		inFolder := folder == "" || strings.HasPrefix(node.ID, folder+"/")
+		tagged := filter.Tag == "" || obsidian.HasTag(node.Tags, filter.Tag)
+		if node.ID == center || inFolder && tagged {
+			keptNotes[node.ID] = true
+		}
+	}
+	// Attachments follow the notes linking to them.
+	linkedAttachments := map[string]bool{}
+	for _, edge := range g.Edges {
+		if keptNotes[edge.Source] {
+			linkedAttachments[edge.Target] = true
+		}
+	}
+	g = g.Filter(func(node graph.Node) bool {
+		if node.Attachment {
+			return linkedAttachments[node.ID]
+		}
+		return keptNotes[node.ID]
+	})
+
+	if center != "" {
+		depth := filter.Depth
+		if depth <= 0 {
+			depth = 1
+		}
+		g = g.Neighborhood(center, depth)
+	}
+	return g, nil
+}
+
+type GraphStatsParams struct {
+	GraphFilter
+	Top int
+}
+
+// GraphStats summarises the link graph of a vault. Each component lists its
+// notes from the highest to the lowest PageRank.
+type GraphStats struct {
+	Notes      int
+	Links      int
+	MostLinked []graph.Score
+	PageRank   []graph.Score
+	Components [][]string
+	DeadEnds   []string
+}
+
+func GetGraphStats(vault obsidian.VaultManager, params GraphStatsParams) (GraphStats, error) {
+	vaultName, err := vault.DefaultName()
+	if err != nil {
+		return GraphStats{}, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return GraphStats{}, err
+	}
+
+	g, _, err := buildGraph(vaultName, vaultPath, params.GraphFilter)
+	if err != nil {
+		return GraphStats{}, err
+	}
+
+	pageRank := g.PageRank()
+	order := map[string]int{}
+	for i, score := range pageRank {
+		order[score.ID] = i
+	}
+	components := g.Components()
+	for _, component := range components {
+		sort.Slice(component, func(i, j int) bool { return order[component[i]] < order[component[j]] })
+	}
+
+	stats := GraphStats{
+		Notes:      len(g.Nodes),
+		Links:      len(g.Edges),
+		MostLinked: g.Backlinks(),
+		PageRank:   pageRank,
+		Components: components,
+		DeadEnds:   g.DeadEnds(),
+	}
+	if params.Top > 0 {
+		if len(stats.MostLinked) > params.Top {
+			stats.MostLinked = stats.MostLinked[:params.Top]
+		}
+		if len(stats.PageRank) > params.Top {
+			stats.PageRank = stats.PageRank[:params.Top]
+		}
+	}
+	return stats, nil
+}
+
+type GraphPathParams struct {
+	From     string
+	To       string
+	Directed bool
+}
+
+// FindGraphPath returns the vault relative paths of the notes on the shortest
+// chain of links between two notes.
+func FindGraphPath(vault obsidian.VaultManager, params GraphPathParams) ([]string, error) {
+	vaultName, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	g, index, err := buildGraph(vaultName, vaultPath, GraphFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	from, ok := index.Resolve("", params.From)
+	if !ok {
+		return nil, errors.New(obsidian.NoteDoesNotExistError)
+	}
+	to, ok := index.Resolve("", params.To)
+	if !ok {
+		return nil, errors.New(obsidian.NoteDoesNotExistError)
+	}
+	path, ok := g.ShortestPath(from, to, params.Directed)
+	if !ok {
+		return nil, errors.New(graph.NoPathError)
+	}
+	return path, nil
+}
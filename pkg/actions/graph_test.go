@@ -0,0 +1,154 @@
+package actions_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/graph"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func graphVault(t *testing.T) mocks.MockVaultOperator {
+	notes := map[string]string{
+		"Hub.md":         "---\ntags: [proj]\nstatus: active\n---\nLinks to [[Spoke]], [[Spoke|again]] and ![[pic.png]]\n",
+		"work/Spoke.md":  "Back to [[Hub]] and on to [Far](Far.md) #proj/alpha\n",
+		"Far.md":         "Nothing here\n",
+		"attach/pic.png": "",
+		"work/Lonely.md": "[[Missing]]\n",
+	}
+	return mocks.MockVaultOperator{Name: "myVault", VaultPath: mocks.CreateVaultFiles(t, notes)}
+}
+
+func exportGraph(t *testing.T, vault mocks.MockVaultOperator, params actions.GraphExportParams) graph.Graph {
+	var b bytes.Buffer
+	params.Format = graph.FormatJSON
+	assert.NoError(t, actions.ExportGraph(&vault, params, &b))
+	var g graph.Graph
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &g))
+	return g
+}
+
+func nodeIDs(g graph.Graph) []string {
+	ids := []string{}
+	for _, node := range g.Nodes {
+		ids = append(ids, node.ID)
+	}
+	return ids
+}
+
+func TestExportGraph(t *testing.T) {
+	t.Run("Exports notes with tags, properties and links", func(t *testing.T) {
+		// Arrange
+		vault := graphVault(t)
+		// Act
+		g := exportGraph(t, vault, actions.GraphExportParams{})
+		// Assert
+		assert.Equal(t, "myVault", g.Name)
+		assert.Equal(t, []string{"Far.md", "Hub.md", "work/Lonely.md", "work/Spoke.md"}, nodeIDs(g))
+		hub, _ := g.Node("Hub.md")
+		assert.Equal(t, "Hub", hub.Label)
+		assert.Equal(t, []string{"proj"}, hub.Tags)
+		assert.Equal(t, map[string]interface{}{"status": "active"}, hub.Properties)
+		assert.Equal(t, []graph.Edge{
+			{Source: "Hub.md", Target: "work/Spoke.md"},
+			{Source: "work/Spoke.md", Target: "Far.md"},
+			{Source: "work/Spoke.md", Target: "Hub.md"},
+		}, g.Edges)
+	})
+
+	t.Run("Includes attachments", func(t *testing.T) {
+		vault := graphVault(t)
+		g := exportGraph(t, vault, actions.GraphExportParams{GraphFilter: actions.GraphFilter{Attachments: true}})
+		pic, ok := g.Node("attach/pic.png")
+		assert.True(t, ok)
+		assert.True(t, pic.Attachment)
+		assert.Contains(t, g.Edges, graph.Edge{Source: "Hub.md", Target: "attach/pic.png"})
+	})
+
+	t.Run("Filters by folder and tag", func(t *testing.T) {
+		vault := graphVault(t)
+		g := exportGraph(t, vault, actions.GraphExportParams{GraphFilter: actions.GraphFilter{Folder: "work"}})
+		assert.Equal(t, []string{"work/Lonely.md", "work/Spoke.md"}, nodeIDs(g))
+		g = exportGraph(t, vault, actions.GraphExportParams{GraphFilter: actions.GraphFilter{Tag: "#proj"}})
+		assert.Equal(t, []string{"Hub.md", "work/Spoke.md"}, nodeIDs(g))
+	})
+
+	t.Run("Neighborhood of a note", func(t *testing.T) {
+		vault := graphVault(t)
+		g := exportGraph(t, vault, actions.GraphExportParams{GraphFilter: actions.GraphFilter{Note: "Far", Depth: 1}})
+		assert.Equal(t, []string{"Far.md", "work/Spoke.md"}, nodeIDs(g))
+		g = exportGraph(t, vault, actions.GraphExportParams{GraphFilter: actions.GraphFilter{Note: "Far", Depth: 2}})
+		assert.Equal(t, []string{"Far.md", "Hub.md", "work/Spoke.md"}, nodeIDs(g))
+	})
+
+	t.Run("Unknown note", func(t *testing.T) {
+		vault := graphVault(t)
+		params := actions.GraphExportParams{GraphFilter: actions.GraphFilter{Note: "Nope"}, Format: graph.FormatDOT}
+		err := actions.ExportGraph(&vault, params, &bytes.Buffer{})
+		assert.EqualError(t, err, obsidian.NoteDoesNotExistError)
+	})
+
+	t.Run("Unknown format", func(t *testing.T) {
+		vault := graphVault(t)
+		err := actions.ExportGraph(&vault, actions.GraphExportParams{Format: "svg"}, &bytes.Buffer{})
+		assert.EqualError(t, err, graph.UnknownFormatError)
+	})
+
+	t.Run("Error in getting vault path", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", PathError: errors.New("path error")}
+		err := actions.ExportGraph(&vault, actions.GraphExportParams{Format: graph.FormatJSON}, &bytes.Buffer{})
+		assert.EqualError(t, err, "path error")
+	})
+}
+
+func TestGetGraphStats(t *testing.T) {
+	t.Run("Summarises the graph", func(t *testing.T) {
+		// Arrange
+		vault := graphVault(t)
+		// Act
+		stats, err := actions.GetGraphStats(&vault, actions.GraphStatsParams{Top: 2})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 4, stats.Notes)
+		assert.Equal(t, 3, stats.Links)
+		assert.Len(t, stats.MostLinked, 2)
+		assert.Len(t, stats.PageRank, 2)
+		assert.Equal(t, [][]string{{"work/Spoke.md", "Far.md", "Hub.md"}, {"work/Lonely.md"}}, stats.Components)
+		assert.Equal(t, []string{"Far.md", "work/Lonely.md"}, stats.DeadEnds)
+	})
+
+	t.Run("Error in getting vault path", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", PathError: errors.New("path error")}
+		_, err := actions.GetGraphStats(&vault, actions.GraphStatsParams{})
+		assert.EqualError(t, err, "path error")
+	})
+}
+
+func TestFindGraphPath(t *testing.T) {
+	t.Run("Finds the chain of links between two notes", func(t *testing.T) {
+		// Arrange
+		vault := graphVault(t)
+		// Act
+		path, err := actions.FindGraphPath(&vault, actions.GraphPathParams{From: "Far", To: "Hub"})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Far.md", "work/Spoke.md", "Hub.md"}, path)
+	})
+
+	t.Run("No path", func(t *testing.T) {
+		vault := graphVault(t)
+		_, err := actions.FindGraphPath(&vault, actions.GraphPathParams{From: "Hub", To: "Lonely"})
+		assert.EqualError(t, err, graph.NoPathError)
+	})
+
+	t.Run("Unknown note", func(t *testing.T) {
+		vault := graphVault(t)
+		_, err := actions.FindGraphPath(&vault, actions.GraphPathParams{From: "Hub", To: "Nope"})
+		assert.EqualError(t, err, obsidian.NoteDoesNotExistError)
+	})
+}
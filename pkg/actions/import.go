@@ -0,0 +1,249 @@
+package actions
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/ics"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	DefaultMeetingsFolder = "Meetings"
+	meetingsHeading       = "## Meetings"
+	meetingTimeLayout     = "2006-01-02T15:04"
+)
+
+type ImportICSParams struct {
+	Date      time.Time
+	Folder    string
+	Template  string
+	LinkDaily bool
+}
+
+// ImportResult lists the vault relative paths of the meeting notes that were
+// created and of those skipped because they already exist.
+type ImportResult struct {
+	Created []string
+	Skipped []string
+}
+
+// meetingProperties is the frontmatter of a meeting note, in the order it is
+// written. The names match what export ics reads back.
+type meetingProperties struct {
+	Title     string   `yaml:"title"`
+	Date      yamlDate `yaml:"date"`
+	Start     yamlDate `yaml:"start,omitempty"`
+	End       yamlDate `yaml:"end,omitempty"`
+	Location  string   `yaml:"location,omitempty"`
+	Organizer string   `yaml:"organizer,omitempty"`
+	Attendees []string `yaml:"attendees,omitempty"`
+}
+
+// yamlDate is written unquoted, the way Obsidian writes date properties.
+type yamlDate string
+
+func (d yamlDate) MarshalYAML() (interface{}, error) {
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: string(d)}, nil
+}
+
+var noteNameReplacer = strings.NewReplacer(
+	"/", "-", "\\", "-", ":", "-", "|", "-",
+	"*", "", "?", "", "\"", "", "<", "", ">", "", "#", "", "^", "", "[", "", "]", "",
+)
+
+// ImportICS creates a note for every event of the calendar taking place on
+// params.Date, in params.Folder. Existing notes are left alone so the import
+// can be run again after the calendar changes.
+func ImportICS(vault obsidian.VaultManager, calendarFile io.Reader, params ImportICSParams) (ImportResult, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	calendar, err := ics.Decode(calendarFile)
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	date := params.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	folder := params.Folder
+	if folder == "" {
+		folder = DefaultMeetingsFolder
+	}
+
+	result := ImportResult{}
+	var links []string
+	used := map[string]bool{}
+	for _, event := range ics.EventsOn(calendar, date) {
+		name := meetingNoteName(event, used)
+		notePath := obsidian.AddMdSuffix(filepath.Join(folder, name))
+		links = append(links, "- [["+name+"]]")
+
+		fullPath := filepath.Join(vaultPath, notePath)
+		if _, err := os.Stat(fullPath); err == nil {
+			result.Skipped = append(result.Skipped, notePath)
+			continue
+		}
+
+		content, err := meetingNoteContent(vaultPath, event, params.Template)
+		if err != nil {
+			return result, err
+		}
+		err = os.MkdirAll(filepath.Dir(fullPath), 0755)
+		if err != nil {
+			return result, errors.New(obsidian.VaultWriteError)
+		}
+		err = os.WriteFile(fullPath, []byte(content), 0644)
+		if err != nil {
+			return result, errors.New(obsidian.VaultWriteError)
+		}
+		result.Created = append(result.Created, notePath)
+	}
+
+	if params.LinkDaily && len(links) > 0 {
+		err = linkMeetingsInDailyNote(vaultPath, date, links)
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// meetingNoteName names a meeting note after its date and summary, adding
+// the start time when two meetings of the day share a summary.
+func meetingNoteName(event ics.Event, used map[string]bool) string {
+	summary := strings.Join(strings.Fields(noteNameReplacer.Replace(event.Summary)), " ")
+	if summary == "" {
+		summary = "Meeting"
+	}
+	start := event.Start.In(time.Local)
+	name := start.Format(obsidian.DateLayout) + " " + summary
+	if used[name] && !event.AllDay {
+		name += " " + start.Format("1504")
+	}
+	for i := 2; used[name]; i++ {
+		name = start.Format(obsidian.DateLayout) + " " + summary + " " + strconv.Itoa(i)
+	}
+	used[name] = true
+	return name
+}
+
+func meetingNoteContent(vaultPath string, event ics.Event, templateName string) (string, error) {
+	start := event.Start.In(time.Local)
+	properties := meetingProperties{
+		Title:     event.Summary,
+		Date:      yamlDate(start.Format(obsidian.DateLayout)),
+		Location:  event.Location,
+		Organizer: event.Organizer,
+		Attendees: event.Attendees,
+	}
+	if !event.AllDay {
+		properties.Start = yamlDate(start.Format(meetingTimeLayout))
+		properties.End = yamlDate(event.End.In(time.Local).Format(meetingTimeLayout))
+	} else if days := int(event.End.Sub(event.Start).Hours()/24 + 0.5); days > 1 {
+		// The end date of a note is inclusive.
+		properties.Start = properties.Date
+		properties.End = yamlDate(event.Start.AddDate(0, 0, days-1).Format(obsidian.DateLayout))
+	}
+
+	frontmatter, err := yaml.Marshal(properties)
+	if err != nil {
+		return "", errors.New(obsidian.VaultWriteError)
+	}
+
+	body := event.Description
+	if templateName != "" {
+		rendered, err := renderNoteTemplate(vaultPath, templateName, obsidian.TemplateData{
+			Title: event.Summary,
+			Date:  start,
+			Variables: map[string]string{
+				"location":    event.Location,
+				"organizer":   event.Organizer,
+				"attendees":   strings.Join(event.Attendees, ", "),
+				"description": event.Description,
+				"start":       start.Format("15:04"),
+				"end":         event.End.In(time.Local).Format("15:04"),
+			},
+		}, nil)
+		if err != nil {
+			return "", err
+		}
+
+		// Keep the template's own properties after the meeting's.
+		templateProperties, templateBody, err := obsidian.ParseFrontmatter(rendered)
+		if err != nil {
+			return "", err
+		}
+		extra := map[string]interface{}{}
+		for key, value := range templateProperties {
+			if !isMeetingProperty(key) {
+				extra[key] = value
+			}
+		}
+		if len(extra) > 0 {
+			more, err := yaml.Marshal(extra)
+			if err != nil {
+				return "", errors.New(obsidian.VaultWriteError)
+			}
+			frontmatter = append(frontmatter, more...)
+		}
+		body = templateBody
+	}
+
+	content := "---\n" + string(frontmatter) + "---\n"
+	if body != "" {
+		content += strings.TrimLeft(body, "\n")
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+	}
+	return content, nil
+}
+
+func isMeetingProperty(key string) bool {
+	switch key {
+	case "title", "date", "start", "end", "location", "organizer", "attendees":
+		return true
+	}
+	return false
+}
+
+// linkMeetingsInDailyNote lists the meeting notes under a Meetings heading in
+// the daily note, leaving out links that are already there.
+func linkMeetingsInDailyNote(vaultPath string, date time.Time, links []string) error {
+	notePath, err := obsidian.CreateDailyNote(vaultPath, date)
+	if err != nil {
+		return err
+	}
+	fullPath := filepath.Join(vaultPath, notePath)
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		return errors.New(obsidian.VaultReadError)
+	}
+
+	var missing []string
+	for _, link := range links {
+		if !strings.Contains(string(content), strings.TrimPrefix(link, "- ")) {
+			missing = append(missing, link)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return obsidian.InsertIntoNote(fullPath, meetingsHeading, strings.Join(missing, "\n"), false)
+}
@@ -0,0 +1,79 @@
+package actions_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+const meetingsCalendar = "BEGIN:VCALENDAR\r\n" +
+	"BEGIN:VEVENT\r\nSUMMARY:Weekly sync\r\nDTSTART:20240110T090000\r\nDTEND:20240110T093000\r\n" +
+	"LOCATION:Room 1\r\nATTENDEE;CN=Alice:mailto:alice@example.com\r\nEND:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\nSUMMARY:Design: review\r\nDTSTART:20240110T140000\r\nDTEND:20240110T150000\r\nEND:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\nSUMMARY:Tomorrow\r\nDTSTART:20240111T140000\r\nEND:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestImportICS(t *testing.T) {
+	date := time.Date(2024, 1, 10, 8, 0, 0, 0, time.Local)
+
+	t.Run("Creates a note per meeting of the day", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		// Act
+		result, err := actions.ImportICS(&vault, strings.NewReader(meetingsCalendar), actions.ImportICSParams{Date: date})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			filepath.Join("Meetings", "2024-01-10 Weekly sync.md"),
+			filepath.Join("Meetings", "2024-01-10 Design- review.md"),
+		}, result.Created)
+		content, _ := os.ReadFile(filepath.Join(vault.VaultPath, "Meetings", "2024-01-10 Weekly sync.md"))
+		assert.Equal(t, "---\ntitle: Weekly sync\ndate: 2024-01-10\nstart: 2024-01-10T09:00\nend: 2024-01-10T09:30\nlocation: Room 1\nattendees:\n    - Alice\n---\n", string(content))
+	})
+
+	t.Run("Skips existing notes and links the daily note", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		existing := filepath.Join(vault.VaultPath, "Calls", "2024-01-10 Weekly sync.md")
+		assert.NoError(t, os.MkdirAll(filepath.Dir(existing), 0755))
+		assert.NoError(t, os.WriteFile(existing, []byte("my notes"), 0644))
+		params := actions.ImportICSParams{Date: date, Folder: "Calls", LinkDaily: true}
+		// Act
+		result, err := actions.ImportICS(&vault, strings.NewReader(meetingsCalendar), params)
+		assert.NoError(t, err)
+		_, err = actions.ImportICS(&vault, strings.NewReader(meetingsCalendar), params)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join("Calls", "2024-01-10 Weekly sync.md")}, result.Skipped)
+		content, _ := os.ReadFile(existing)
+		assert.Equal(t, "my notes", string(content))
+		daily, _ := os.ReadFile(filepath.Join(vault.VaultPath, "2024-01-10.md"))
+		assert.Equal(t, "## Meetings\n- [[2024-01-10 Weekly sync]]\n- [[2024-01-10 Design- review]]\n", string(daily))
+	})
+
+	t.Run("Uses a template", func(t *testing.T) {
+		// Arrange
+		vaultPath := createTemplatesVault(t, map[string]string{"Meeting.md": "---\ntags: [meeting]\n---\n# {{title}} at {{start}}\nWith {{attendees}}\n"})
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: vaultPath}
+		params := actions.ImportICSParams{Date: date, Template: "Meeting"}
+		// Act
+		_, err := actions.ImportICS(&vault, strings.NewReader(meetingsCalendar), params)
+		// Assert
+		assert.NoError(t, err)
+		content, _ := os.ReadFile(filepath.Join(vaultPath, "Meetings", "2024-01-10 Weekly sync.md"))
+		assert.Contains(t, string(content), "attendees:\n    - Alice\ntags:\n    - meeting\n---\n# Weekly sync at 09:00\nWith Alice\n")
+	})
+
+	t.Run("vault.DefaultName returns an error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{DefaultNameErr: errors.New("Failed to get vault name")}
+		_, err := actions.ImportICS(&vault, strings.NewReader(meetingsCalendar), actions.ImportICSParams{})
+		assert.Equal(t, vault.DefaultNameErr, err)
+	})
+}
@@ -0,0 +1,100 @@
+package actions
+
+import (
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+type MentionsParams struct {
+	NoteName string
+	// Confirm is asked about each mention before it is linked. Without it
+	// every mention is linked.
+	Confirm func(obsidian.Mention) bool
+}
+
+// FindMentions lists the places in other notes that mention the title or an
+// alias of a note without linking to it.
+func FindMentions(vault obsidian.VaultManager, params MentionsParams) ([]obsidian.Mention, error) {
+	var mentions []obsidian.Mention
+	err := walkMentions(vault, params.NoteName, func(vaultPath string, relPath string, content string, target string, names []string) error {
+		mentions = append(mentions, obsidian.FindMentions(relPath, content, names)...)
+		return nil
+	})
+	return mentions, err
+}
+
+// LinkMentions turns the unlinked mentions of a note into links to it and
+// returns the mentions linked.
+func LinkMentions(vault obsidian.VaultManager, params MentionsParams) ([]obsidian.Mention, error) {
+	var linked []obsidian.Mention
+	err := walkMentions(vault, params.NoteName, func(vaultPath string, relPath string, content string, target string, names []string) error {
+		updated, noteLinked := obsidian.LinkMentions(relPath, content, target, names, params.Confirm)
+		if len(noteLinked) == 0 {
+			return nil
+		}
+		linked = append(linked, noteLinked...)
+
+		notePath := filepath.Join(vaultPath, relPath)
+		info, err := os.Stat(notePath)
+		if err != nil {
+			return errors.New(obsidian.VaultReadError)
+		}
+		err = os.WriteFile(notePath, []byte(updated), info.Mode())
+		if err != nil {
+			return errors.New(obsidian.VaultWriteError)
+		}
+		return nil
+	})
+	return linked, err
+}
+
+// walkMentions calls fn for every note other than the one mentioned, with the
+// link target and the names of the mentioned note.
+func walkMentions(vault obsidian.VaultManager, noteName string, fn func(vaultPath string, relPath string, content string, target string, names []string) error) error {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return err
+	}
+
+	notePath, err := obsidian.ResolveNotePath(vaultPath, noteName)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(notePath)
+	if err != nil {
+		return errors.New(obsidian.VaultReadError)
+	}
+	relPath, err := filepath.Rel(vaultPath, notePath)
+	if err != nil {
+		return errors.New(obsidian.VaultAccessError)
+	}
+	relPath = filepath.ToSlash(relPath)
+	title := obsidian.RemoveMdSuffix(path.Base(relPath))
+	names := obsidian.MentionNames(title, string(content))
+
+	// Links use the title unless another note has the same name.
+	target := title
+	index, err := obsidian.BuildNoteIndex(vaultPath)
+	if err != nil {
+		return err
+	}
+	if file, ok := index.Resolve("", title); !ok || file != relPath {
+		target = obsidian.RemoveMdSuffix(relPath)
+	}
+
+	return obsidian.WalkNotes(vaultPath, func(otherPath string, otherContent []byte) error {
+		if filepath.ToSlash(otherPath) == relPath {
+			return nil
+		}
+		return fn(vaultPath, otherPath, string(otherContent), target, names)
+	})
+}
@@ -0,0 +1,76 @@
+package actions_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func mentionsVault(t *testing.T) mocks.MockVaultOperator {
+	notes := map[string]string{
+		"Apollo.md":      "---\naliases: [Moonshot]\n---\nApollo is the moonshot.\n",
+		"log.md":         "Worked on apollo today.\nThe Moonshot slipped, see [[Apollo]].\n",
+		"other/notes.md": "Nothing to see.\n",
+	}
+	return mocks.MockVaultOperator{Name: "myVault", VaultPath: mocks.CreateVaultFiles(t, notes)}
+}
+
+func TestFindMentions(t *testing.T) {
+	t.Run("Finds mentions in other notes", func(t *testing.T) {
+		// Arrange
+		vault := mentionsVault(t)
+		// Act
+		mentions, err := actions.FindMentions(&vault, actions.MentionsParams{NoteName: "Apollo"})
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, mentions, 2)
+		assert.Equal(t, "apollo", mentions[0].Text)
+		assert.Equal(t, "Moonshot", mentions[1].Text)
+		assert.Equal(t, 2, mentions[1].LineNumber)
+	})
+
+	t.Run("Unknown note", func(t *testing.T) {
+		vault := mentionsVault(t)
+		_, err := actions.FindMentions(&vault, actions.MentionsParams{NoteName: "Gemini"})
+		assert.EqualError(t, err, obsidian.NoteDoesNotExistError)
+	})
+
+	t.Run("Error in getting vault path", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", PathError: errors.New("path error")}
+		_, err := actions.FindMentions(&vault, actions.MentionsParams{NoteName: "Apollo"})
+		assert.EqualError(t, err, "path error")
+	})
+}
+
+func TestLinkMentions(t *testing.T) {
+	t.Run("Links every mention", func(t *testing.T) {
+		// Arrange
+		vault := mentionsVault(t)
+		// Act
+		linked, err := actions.LinkMentions(&vault, actions.MentionsParams{NoteName: "Apollo"})
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, linked, 2)
+		content, _ := os.ReadFile(filepath.Join(vault.VaultPath, "log.md"))
+		assert.Equal(t, "Worked on [[Apollo|apollo]] today.\nThe [[Apollo|Moonshot]] slipped, see [[Apollo]].\n", string(content))
+	})
+
+	t.Run("Links the mentions confirmed", func(t *testing.T) {
+		vault := mentionsVault(t)
+		params := actions.MentionsParams{
+			NoteName: "Apollo",
+			Confirm:  func(m obsidian.Mention) bool { return m.Text == "Moonshot" },
+		}
+		linked, err := actions.LinkMentions(&vault, params)
+		assert.NoError(t, err)
+		assert.Len(t, linked, 1)
+		content, _ := os.ReadFile(filepath.Join(vault.VaultPath, "log.md"))
+		assert.Equal(t, "Worked on apollo today.\nThe [[Apollo|Moonshot]] slipped, see [[Apollo]].\n", string(content))
+	})
+}
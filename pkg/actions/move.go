@@ -1,6 +1,8 @@
 package actions
 
 import (
+	"errors"
+	"os"
 	"path/filepath"
 
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
@@ -23,7 +25,8 @@ func MoveNote(vault obsidian.VaultManager, note obsidian.NoteManager, uri obsidi
 		return err
 	}
 
-	currentPath := filepath.Join(vaultPath, params.CurrentNoteName)
+	currentNoteName := obsidian.ResolveNoteName(vaultPath, params.CurrentNoteName)
+	currentPath := filepath.Join(vaultPath, currentNoteName)
 	newPath := filepath.Join(vaultPath, params.NewNoteName)
 
 	err = note.Move(currentPath, newPath)
@@ -31,7 +34,7 @@ func MoveNote(vault obsidian.VaultManager, note obsidian.NoteManager, uri obsidi
 		return err
 	}
 
-	err = note.UpdateLinks(vaultPath, params.CurrentNoteName, params.NewNoteName)
+	err = note.UpdateLinks(vaultPath, currentNoteName, params.NewNoteName)
 	if err != nil {
 		return err
 	}
@@ -55,3 +58,49 @@ func MoveNote(vault obsidian.VaultManager, note obsidian.NoteManager, uri obsidi
 
 	return nil
 }
+
+type MoveToFolderParams struct {
+	NoteNames []string
+	Folder    string
+}
+
+// MoveNotesToFolder moves notes into a folder of the vault, keeping their
+// file names, and updates the links to them. The folder is created if
+// needed. It returns the new vault relative paths of the notes.
+func MoveNotesToFolder(vault obsidian.VaultManager, note obsidian.NoteManager, uri obsidian.UriManager, params MoveToFolderParams) ([]string, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	folder := filepath.Clean(filepath.FromSlash(params.Folder))
+	err = os.MkdirAll(filepath.Join(vaultPath, folder), 0755)
+	if err != nil {
+		return nil, errors.New(obsidian.VaultWriteError)
+	}
+
+	var moved []string
+	for _, noteName := range params.NoteNames {
+		relPath, err := noteRelPath(vaultPath, noteName)
+		if err != nil {
+			return moved, err
+		}
+		newPath := filepath.Join(folder, filepath.Base(relPath))
+		if newPath == relPath {
+			continue
+		}
+		err = MoveNote(vault, note, uri, MoveParams{
+			CurrentNoteName: obsidian.RemoveMdSuffix(relPath),
+			NewNoteName:     obsidian.RemoveMdSuffix(newPath),
+		})
+		if err != nil {
+			return moved, err
+		}
+		moved = append(moved, newPath)
+	}
+	return moved, nil
+}
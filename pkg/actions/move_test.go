@@ -3,10 +3,12 @@ package actions_test
 import (
 	"errors"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/Yakitrak/obsidian-cli/mocks"
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -166,3 +168,44 @@ func TestMoveNote(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestMoveNotesToFolder(t *testing.T) {
+	t.Run("Moves notes into the folder", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		uri := mocks.MockUriManager{}
+		note := mocks.MockNoteManager{}
+		assert.NoError(t, os.MkdirAll(filepath.Join(vault.VaultPath, "Inbox"), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(vault.VaultPath, "Inbox", "idea.md"), []byte(""), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(vault.VaultPath, "todo.md"), []byte(""), 0644))
+		// Act
+		moved, err := actions.MoveNotesToFolder(&vault, &note, &uri, actions.MoveToFolderParams{
+			NoteNames: []string{"idea", "todo.md"},
+			Folder:    "Archive/2024",
+		})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join("Archive", "2024", "idea.md"), filepath.Join("Archive", "2024", "todo.md")}, moved)
+		assert.DirExists(t, filepath.Join(vault.VaultPath, "Archive", "2024"))
+	})
+
+	t.Run("Unknown note", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		_, err := actions.MoveNotesToFolder(&vault, &mocks.MockNoteManager{}, &mocks.MockUriManager{}, actions.MoveToFolderParams{
+			NoteNames: []string{"missing"},
+			Folder:    "Archive",
+		})
+		assert.EqualError(t, err, obsidian.NoteDoesNotExistError)
+	})
+
+	t.Run("note.Move returns an error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		assert.NoError(t, os.WriteFile(filepath.Join(vault.VaultPath, "todo.md"), []byte(""), 0644))
+		note := mocks.MockNoteManager{MoveErr: errors.New("move error")}
+		_, err := actions.MoveNotesToFolder(&vault, &note, &mocks.MockUriManager{}, actions.MoveToFolderParams{
+			NoteNames: []string{"todo"},
+			Folder:    "Archive",
+		})
+		assert.EqualError(t, err, "move error")
+	})
+}
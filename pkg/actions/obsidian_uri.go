@@ -0,0 +1,74 @@
+package actions
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+// SearchInObsidian opens the search pane of Obsidian with a query.
+func SearchInObsidian(vault obsidian.VaultManager, uri obsidian.UriManager, query string) error {
+	vaultName, err := vault.DefaultName()
+	if err != nil {
+		return err
+	}
+
+	return uri.Execute(uri.Construct(ObsSearchUrl, map[string]string{
+		"vault": vaultName,
+		"query": query,
+	}))
+}
+
+// CopyActiveNoteLink has Obsidian copy a Markdown link to the note open in
+// it to the clipboard, as it does for Hook. When callback is given, Obsidian
+// calls it with the link instead, as an x-callback-url.
+func CopyActiveNoteLink(vault obsidian.VaultManager, uri obsidian.UriManager, callback string) error {
+	vaultName, err := vault.DefaultName()
+	if err != nil {
+		return err
+	}
+
+	return uri.Execute(uri.Construct(ObsHookGetAddressUrl, map[string]string{
+		"vault":     vaultName,
+		"x-success": callback,
+	}))
+}
+
+type FrontmatterParams struct {
+	NoteName string
+	Key      string
+	Value    string
+}
+
+// WriteFrontmatterInObsidian sets a frontmatter property of a note through
+// the Advanced URI plugin, so that Obsidian makes the change itself. URIs
+// leave out empty and false parameters, so those values cannot be written.
+func WriteFrontmatterInObsidian(vault obsidian.VaultManager, uri obsidian.UriManager, params FrontmatterParams) error {
+	key := strings.TrimSpace(params.Key)
+	if key == "" {
+		return errors.New(obsidian.InvalidPropertyError)
+	}
+	if params.Value == "" || params.Value == "false" {
+		return errors.New(obsidian.PropertyValueUriError)
+	}
+
+	vaultName, err := vault.DefaultName()
+	if err != nil {
+		return err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return err
+	}
+	file := obsidian.ResolveNoteName(vaultPath, params.NoteName)
+
+	return uri.Execute(uri.Construct(ObsAdvancedUrl, map[string]string{
+		"vault":          vaultName,
+		"filepath":       filepath.ToSlash(obsidian.AddMdSuffix(file)),
+		"frontmatterkey": key,
+		"data":           params.Value,
+	}))
+}
@@ -0,0 +1,65 @@
+package actions_test
+
+import (
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchInObsidian(t *testing.T) {
+	// Arrange
+	vault := mocks.MockVaultOperator{Name: "myVault"}
+	uri := mocks.MockUriManager{}
+	// Act
+	err := actions.SearchInObsidian(&vault, &uri, "tag:#project")
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, actions.ObsSearchUrl, uri.BaseUri)
+	assert.Equal(t, map[string]string{"vault": "myVault", "query": "tag:#project"}, uri.Params)
+}
+
+func TestCopyActiveNoteLink(t *testing.T) {
+	// Arrange
+	vault := mocks.MockVaultOperator{Name: "myVault"}
+	uri := mocks.MockUriManager{}
+	// Act
+	err := actions.CopyActiveNoteLink(&vault, &uri, "hook://x-callback-url/link")
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, actions.ObsHookGetAddressUrl, uri.BaseUri)
+	assert.Equal(t, "hook://x-callback-url/link", uri.Params["x-success"])
+}
+
+func TestWriteFrontmatterInObsidian(t *testing.T) {
+	t.Run("Writes a property", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		uri := mocks.MockUriManager{}
+		// Act
+		err := actions.WriteFrontmatterInObsidian(&vault, &uri, actions.FrontmatterParams{NoteName: "note", Key: "status", Value: "done"})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, actions.ObsAdvancedUrl, uri.BaseUri)
+		assert.Equal(t, map[string]string{
+			"vault":          "myVault",
+			"filepath":       "note.md",
+			"frontmatterkey": "status",
+			"data":           "done",
+		}, uri.Params)
+	})
+
+	t.Run("Empty property name", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		err := actions.WriteFrontmatterInObsidian(&vault, &mocks.MockUriManager{}, actions.FrontmatterParams{NoteName: "note", Value: "done"})
+		assert.EqualError(t, err, obsidian.InvalidPropertyError)
+	})
+
+	t.Run("False value", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		err := actions.WriteFrontmatterInObsidian(&vault, &mocks.MockUriManager{}, actions.FrontmatterParams{NoteName: "note", Key: "draft", Value: "false"})
+		assert.EqualError(t, err, obsidian.PropertyValueUriError)
+	})
+}
@@ -1,11 +1,19 @@
 package actions
 
 import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 )
 
 type OpenParams struct {
 	NoteName string
+	Heading  string
+	Line     int
+	NewPane  bool
 }
 
 func OpenNote(vault obsidian.VaultManager, uri obsidian.UriManager, params OpenParams) error {
@@ -14,9 +22,40 @@ func OpenNote(vault obsidian.VaultManager, uri obsidian.UriManager, params OpenP
 		return err
 	}
 
+	// Aliases are only a convenience, without the vault path Obsidian is
+	// given the name as it is.
+	file := params.NoteName
+	vaultPath, err := vault.Path()
+	if err == nil {
+		file = filepath.ToSlash(obsidian.ResolveNoteName(vaultPath, params.NoteName))
+	}
+	heading := strings.TrimLeft(strings.TrimSpace(params.Heading), "# ")
+
+	// Obsidian itself cannot open a note at a line or in a new pane, the
+	// Advanced URI plugin can.
+	if params.Line > 0 || params.NewPane {
+		advancedParams := map[string]string{
+			"vault":    vaultName,
+			"filepath": obsidian.AddMdSuffix(file),
+			"heading":  heading,
+		}
+		if params.Line > 0 {
+			advancedParams["line"] = strconv.Itoa(params.Line)
+		}
+		if params.NewPane {
+			advancedParams["openmode"] = newPaneMode
+		}
+		return uri.Execute(uri.Construct(ObsAdvancedUrl, advancedParams))
+	}
+
+	// Obsidian scrolls to the heading given after a # in the file name.
+	if params.Heading != "" {
+		file += "#" + heading
+	}
+
 	obsidianUri := uri.Construct(ObsOpenUrl, map[string]string{
 		"vault": vaultName,
-		"file":  params.NoteName,
+		"file":  file,
 	})
 
 	err = uri.Execute(obsidianUri)
@@ -25,3 +64,30 @@ func OpenNote(vault obsidian.VaultManager, uri obsidian.UriManager, params OpenP
 	}
 	return nil
 }
+
+// OpenNotes opens each of the notes, given by vault relative path, in
+// Obsidian or one after another in the editor.
+func OpenNotes(vault obsidian.VaultManager, uri obsidian.UriManager, notes []string, useEditor bool) error {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return err
+	}
+
+	for _, notePath := range notes {
+		if useEditor {
+			fmt.Printf("Opening note: %s\n", notePath)
+			err = obsidian.OpenInEditor(filepath.Join(vaultPath, notePath))
+		} else {
+			err = OpenNote(vault, uri, OpenParams{NoteName: notePath})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package actions_test
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/Yakitrak/obsidian-cli/mocks"
@@ -22,6 +24,66 @@ func TestOpenNote(t *testing.T) {
 		assert.Equal(t, err, nil)
 	})
 
+	t.Run("Open note at a heading", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		uri := mocks.MockUriManager{}
+		// Act
+		err := actions.OpenNote(&vault, &uri, actions.OpenParams{
+			NoteName: "note",
+			Heading:  "## Setup",
+		})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "note#Setup", uri.Params["file"])
+	})
+
+	t.Run("Open note at a line in a new pane", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		uri := mocks.MockUriManager{}
+		// Act
+		err := actions.OpenNote(&vault, &uri, actions.OpenParams{
+			NoteName: "folder/note",
+			Line:     12,
+			NewPane:  true,
+		})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, actions.ObsAdvancedUrl, uri.BaseUri)
+		assert.Equal(t, map[string]string{
+			"vault":    "myVault",
+			"filepath": "folder/note.md",
+			"heading":  "",
+			"line":     "12",
+			"openmode": "tab",
+		}, uri.Params)
+	})
+
+	t.Run("Open note by alias", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		uri := mocks.MockUriManager{}
+		assert.NoError(t, os.MkdirAll(filepath.Join(vault.VaultPath, "people"), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(vault.VaultPath, "people", "P-0042.md"), []byte("---\naliases: [Jane Doe]\n---\n"), 0644))
+		// Act
+		err := actions.OpenNote(&vault, &uri, actions.OpenParams{NoteName: "jane doe"})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "people/P-0042", uri.Params["file"])
+	})
+
+	t.Run("vault.Path returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", PathError: errors.New("Failed to get vault path")}
+		uri := mocks.MockUriManager{}
+		// Act
+		err := actions.OpenNote(&vault, &uri, actions.OpenParams{NoteName: "jane doe"})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "jane doe", uri.Params["file"])
+	})
+
 	t.Run("vault.DefaultName returns an error", func(t *testing.T) {
 		// Arrange
 		vaultDefaultNameErr := errors.New("Failed to get vault name")
@@ -49,3 +111,22 @@ func TestOpenNote(t *testing.T) {
 		assert.Equal(t, err, uri.ExecuteErr)
 	})
 }
+
+func TestOpenNotes(t *testing.T) {
+	t.Run("Opens each note", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		uri := mocks.MockUriManager{}
+		// Act
+		err := actions.OpenNotes(&vault, &uri, []string{"a.md", "b.md"}, false)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "b.md", uri.Params["file"])
+	})
+
+	t.Run("uri.Execute returns an error", func(t *testing.T) {
+		uri := mocks.MockUriManager{ExecuteErr: errors.New("Failed to execute URI")}
+		err := actions.OpenNotes(&mocks.MockVaultOperator{}, &uri, []string{"a.md"}, false)
+		assert.Equal(t, uri.ExecuteErr, err)
+	})
+}
@@ -0,0 +1,28 @@
+package actions
+
+import (
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+type OutlineParams struct {
+	NoteName string
+}
+
+func Outline(vault obsidian.VaultManager, note obsidian.NoteManager, params OutlineParams) ([]obsidian.Heading, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := note.GetContents(vaultPath, params.NoteName)
+	if err != nil {
+		return nil, err
+	}
+
+	return obsidian.ParseHeadings(contents), nil
+}
@@ -0,0 +1,37 @@
+package actions_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutline(t *testing.T) {
+	t.Run("Returns the headings of the note", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "# Title\ntext\n## Setup\n"}
+		// Act
+		headings, err := actions.Outline(&vault, &note, actions.OutlineParams{NoteName: "note"})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []obsidian.Heading{
+			{Level: 1, Text: "Title", LineNumber: 1},
+			{Level: 2, Text: "Setup", LineNumber: 3},
+		}, headings)
+	})
+
+	t.Run("GetContents returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{GetContentsError: errors.New("Failed to read note")}
+		// Act
+		_, err := actions.Outline(&vault, &note, actions.OutlineParams{NoteName: "note"})
+		// Assert
+		assert.Equal(t, note.GetContentsError, err)
+	})
+}
@@ -0,0 +1,82 @@
+package actions
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+// PickNotes lets several notes be marked in the fuzzy finder and returns
+// their vault relative paths, in the order they were marked.
+func PickNotes(vault obsidian.VaultManager, note obsidian.NoteManager, fuzzyFinder obsidian.FuzzyFinderManager) ([]string, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, labels, preview, err := noteFinderEntries(vaultPath, note)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := fuzzyFinder.FindMulti(labels, func(i int) string {
+		return labels[i]
+	}, preview)
+	if err != nil {
+		return nil, err
+	}
+
+	// A note marked both by its path and by an alias is only picked once.
+	var notes []string
+	seen := map[string]bool{}
+	for _, index := range indexes {
+		if !seen[entries[index].Path] {
+			seen[entries[index].Path] = true
+			notes = append(notes, entries[index].Path)
+		}
+	}
+	return notes, nil
+}
+
+// noteFinderEntries lists the notes of the vault for the fuzzy finder, with
+// their aliases and H1 titles so notes filed by ID can be found by name, and
+// the option previewing the note under the cursor.
+func noteFinderEntries(vaultPath string, note obsidian.NoteManager) ([]obsidian.SearchEntry, []string, interface{}, error) {
+	notes, err := note.GetNotesList(vaultPath)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	entries := obsidian.NoteSearchEntries(vaultPath, notes)
+	labels := make([]string, len(entries))
+	for i, entry := range entries {
+		labels[i] = entry.Label
+	}
+	preview := obsidian.WithPreview(func(i, width, height int) string {
+		if i < 0 {
+			return ""
+		}
+		return obsidian.NotePreview(vaultPath, entries[i].Path, height)
+	})
+	return entries, labels, preview, nil
+}
+
+// noteRelPath finds a note by path, file name or alias and returns its vault
+// relative path.
+func noteRelPath(vaultPath string, noteName string) (string, error) {
+	notePath, err := obsidian.ResolveNotePath(vaultPath, obsidian.ResolveNoteName(vaultPath, noteName))
+	if err != nil {
+		return "", err
+	}
+	relPath, err := filepath.Rel(vaultPath, notePath)
+	if err != nil {
+		return "", errors.New(obsidian.VaultAccessError)
+	}
+	return relPath, nil
+}
@@ -0,0 +1,47 @@
+package actions_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickNotes(t *testing.T) {
+	t.Run("Returns the marked notes", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{}
+		fuzzyFinder := mocks.MockFuzzyFinder{SelectedIndexes: []int{2, 0}}
+		// Act
+		notes, err := actions.PickNotes(&vault, &note, &fuzzyFinder)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"note3", "note1"}, notes)
+	})
+
+	t.Run("A note marked by path and alias is picked once", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		note := mocks.MockNoteManager{}
+		err := os.WriteFile(filepath.Join(vault.VaultPath, "note2"), []byte("---\naliases: [Jane Doe]\n---\n"), 0644)
+		assert.NoError(t, err)
+		fuzzyFinder := mocks.MockFuzzyFinder{SelectedIndexes: []int{1, 3}}
+		// Act
+		notes, err := actions.PickNotes(&vault, &note, &fuzzyFinder)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"note2"}, notes)
+	})
+
+	t.Run("fuzzy find returns error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		fuzzyFinder := mocks.MockFuzzyFinder{FindErr: errors.New("Fuzzy find error")}
+		_, err := actions.PickNotes(&vault, &mocks.MockNoteManager{}, &fuzzyFinder)
+		assert.EqualError(t, err, "Fuzzy find error")
+	})
+}
@@ -5,9 +5,15 @@ import (
 )
 
 type PrintParams struct {
-	NoteName string
+	NoteName        string
+	ResolveEmbeds   bool
+	EmbedDepth      int
+	SkipAttachments bool
 }
 
+// PrintNote returns the contents of a note. A note name such as
+// "Note#Heading" returns only the section under that heading, and
+// "Note#^id" only the block with that ID.
 func PrintNote(vault obsidian.VaultManager, note obsidian.NoteManager, params PrintParams) (string, error) {
 	_, err := vault.DefaultName()
 	if err != nil {
@@ -19,10 +25,27 @@ func PrintNote(vault obsidian.VaultManager, note obsidian.NoteManager, params Pr
 		return "", err
 	}
 
-	contents, err := note.GetContents(vaultPath, params.NoteName)
+	noteName, subpath := obsidian.SplitSubpath(params.NoteName)
+	noteName = obsidian.ResolveNoteName(vaultPath, noteName)
+	contents, err := note.GetContents(vaultPath, noteName)
 	if err != nil {
 		return "", err
 	}
 
+	if subpath != "" {
+		contents, err = obsidian.ExtractSubpath(contents, subpath)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if params.ResolveEmbeds {
+		// The note was read, so its path only fails to resolve with a mock.
+		notePath, _ := obsidian.ResolveNotePath(vaultPath, noteName)
+		contents = obsidian.ResolveEmbeds(vaultPath, notePath, contents, obsidian.EmbedOptions{
+			MaxDepth:        params.EmbedDepth,
+			SkipAttachments: params.SkipAttachments,
+		})
+	}
 	return contents, nil
 }
@@ -2,10 +2,13 @@ package actions_test
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
 	"github.com/Yakitrak/obsidian-cli/mocks"
 	"github.com/Yakitrak/obsidian-cli/pkg/actions"
 	"github.com/stretchr/testify/assert"
-	"testing"
 )
 
 func TestPrintNote(t *testing.T) {
@@ -22,6 +25,34 @@ func TestPrintNote(t *testing.T) {
 		assert.Equal(t, content, "example contents", "Expect matching file contents")
 	})
 
+	t.Run("Successful get section of note", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		note := mocks.MockNoteManager{Contents: "# Title\n## Setup\nsteps\n\n## Usage\nrun\n"}
+		// Act
+		content, err := actions.PrintNote(&vault, &note, actions.PrintParams{
+			NoteName: "note-name#setup",
+		})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "## Setup\nsteps\n", content)
+	})
+
+	t.Run("Successful get block of note with embeds resolved", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		assert.NoError(t, os.WriteFile(filepath.Join(vault.VaultPath, "Other.md"), []byte("Embedded\n"), 0644))
+		note := mocks.MockNoteManager{Contents: "Intro\n\nSee ![[Other]] ^quote\n"}
+		// Act
+		content, err := actions.PrintNote(&vault, &note, actions.PrintParams{
+			NoteName:      "note-name#^quote",
+			ResolveEmbeds: true,
+		})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "See Embedded\n", content)
+	})
+
 	t.Run("vault.DefaultName returns an error", func(t *testing.T) {
 		// Arrange
 		vault := mocks.MockVaultOperator{
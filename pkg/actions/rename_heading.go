@@ -0,0 +1,55 @@
+package actions
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+type RenameHeadingParams struct {
+	NoteName   string
+	OldHeading string
+	NewHeading string
+}
+
+// RenameHeading changes a heading of a note and updates the links to it
+// across the vault, as Obsidian does when a heading is renamed in the app.
+func RenameHeading(vault obsidian.VaultManager, params RenameHeadingParams) error {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return err
+	}
+
+	notePath, err := obsidian.ResolveNotePath(vaultPath, params.NoteName)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(notePath)
+	if err != nil {
+		return errors.New(obsidian.VaultReadError)
+	}
+	content, err := os.ReadFile(notePath)
+	if err != nil {
+		return errors.New(obsidian.VaultReadError)
+	}
+
+	// The level of the new heading always comes from the old one.
+	newText := strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(params.NewHeading), "#"))
+	updated, heading, err := obsidian.RenameHeading(string(content), params.OldHeading, newText)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(notePath, []byte(updated), info.Mode())
+	if err != nil {
+		return errors.New(obsidian.VaultWriteError)
+	}
+
+	return obsidian.UpdateHeadingLinks(vaultPath, params.NoteName, string(content), heading, newText)
+}
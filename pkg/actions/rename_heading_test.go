@@ -0,0 +1,85 @@
+package actions_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenameHeading(t *testing.T) {
+	t.Run("Renames the heading and its links", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		notePath := filepath.Join(vault.VaultPath, "Guide.md")
+		assert.NoError(t, os.WriteFile(notePath, []byte("# Guide\n## Setup\n"), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(vault.VaultPath, "Index.md"), []byte("[[Guide#Setup|setting up]]\n"), 0644))
+		// Act
+		err := actions.RenameHeading(&vault, actions.RenameHeadingParams{
+			NoteName:   "Guide",
+			OldHeading: "setup",
+			NewHeading: "## Installation",
+		})
+		// Assert
+		assert.NoError(t, err)
+		content, _ := os.ReadFile(notePath)
+		assert.Equal(t, "# Guide\n## Installation\n", string(content))
+		index, _ := os.ReadFile(filepath.Join(vault.VaultPath, "Index.md"))
+		assert.Equal(t, "[[Guide#Installation|setting up]]\n", string(index))
+	})
+
+	t.Run("Leaves links to other headings with the same text", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		notePath := filepath.Join(vault.VaultPath, "Guide.md")
+		assert.NoError(t, os.WriteFile(notePath, []byte("## A\n### Old\n## B\n### Old\n"), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(vault.VaultPath, "Index.md"), []byte("[[Guide#A#Old]] [[Guide#B#Old]]\n"), 0644))
+		// Act
+		err := actions.RenameHeading(&vault, actions.RenameHeadingParams{
+			NoteName:   "Guide",
+			OldHeading: "Old",
+			NewHeading: "New",
+		})
+		// Assert
+		assert.NoError(t, err)
+		content, _ := os.ReadFile(notePath)
+		assert.Equal(t, "## A\n### New\n## B\n### Old\n", string(content))
+		index, _ := os.ReadFile(filepath.Join(vault.VaultPath, "Index.md"))
+		assert.Equal(t, "[[Guide#A#New]] [[Guide#B#Old]]\n", string(index))
+	})
+
+	t.Run("Renames a later heading with the same text", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		notePath := filepath.Join(vault.VaultPath, "Log.md")
+		assert.NoError(t, os.WriteFile(notePath, []byte("# Day 1\n## Notes\n# Day 2\n## Notes\n"), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(vault.VaultPath, "Index.md"), []byte("[[Log#Notes]] [[Log#Day 1#Notes]] [[Log#Day 2#Notes]]\n"), 0644))
+		// Act
+		err := actions.RenameHeading(&vault, actions.RenameHeadingParams{
+			NoteName:   "Log",
+			OldHeading: "Day 2#Notes",
+			NewHeading: "Summary",
+		})
+		// Assert
+		assert.NoError(t, err)
+		content, _ := os.ReadFile(notePath)
+		assert.Equal(t, "# Day 1\n## Notes\n# Day 2\n## Summary\n", string(content))
+		index, _ := os.ReadFile(filepath.Join(vault.VaultPath, "Index.md"))
+		assert.Equal(t, "[[Log#Notes]] [[Log#Day 1#Notes]] [[Log#Day 2#Summary]]\n", string(index))
+	})
+
+	t.Run("Missing heading", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		assert.NoError(t, os.WriteFile(filepath.Join(vault.VaultPath, "Guide.md"), []byte("## Setup\n"), 0644))
+		err := actions.RenameHeading(&vault, actions.RenameHeadingParams{
+			NoteName:   "Guide",
+			OldHeading: "Usage",
+			NewHeading: "Install",
+		})
+		assert.EqualError(t, err, obsidian.SectionNotFoundError)
+	})
+}
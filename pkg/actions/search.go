@@ -17,27 +17,28 @@ func SearchNotes(vault obsidian.VaultManager, note obsidian.NoteManager, uri obs
 		return err
 	}
 
-	notes, err := note.GetNotesList(vaultPath)
+	entries, labels, preview, err := noteFinderEntries(vaultPath, note)
 	if err != nil {
 		return err
 	}
 
-	index, err := fuzzyFinder.Find(notes, func(i int) string {
-		return notes[i]
-	})
+	index, err := fuzzyFinder.Find(labels, func(i int) string {
+		return labels[i]
+	}, preview)
 
 	if err != nil {
 		return err
 	}
+	notePath := entries[index].Path
 
 	if useEditor {
-		fmt.Printf("Opening note: %s\n", notes[index])
-		filePath := filepath.Join(vaultPath, notes[index])
+		fmt.Printf("Opening note: %s\n", notePath)
+		filePath := filepath.Join(vaultPath, notePath)
 		return obsidian.OpenInEditor(filePath)
 	}
 
 	obsidianUri := uri.Construct(ObsOpenUrl, map[string]string{
-		"file":  notes[index],
+		"file":  notePath,
 		"vault": vaultName,
 	})
 
@@ -2,7 +2,10 @@ package actions
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 )
@@ -32,7 +35,7 @@ func SearchNotesContent(vault obsidian.VaultManager, note obsidian.NoteManager,
 		fmt.Printf("Opening note: %s\n", matches[0].FilePath)
 		if useEditor {
 			filePath := filepath.Join(vaultPath, matches[0].FilePath)
-			return obsidian.OpenInEditor(filePath)
+			return obsidian.OpenInEditorAt(filePath, matches[0].LineNumber, matchColumn(filePath, matches[0], searchTerm))
 		}
 		obsidianUri := uri.Construct(ObsOpenUrl, map[string]string{
 			"file":  matches[0].FilePath,
@@ -45,7 +48,12 @@ func SearchNotesContent(vault obsidian.VaultManager, note obsidian.NoteManager,
 
 	index, err := fuzzyFinder.Find(displayItems, func(i int) string {
 		return displayItems[i]
-	})
+	}, obsidian.WithPreview(func(i, width, height int) string {
+		if i < 0 {
+			return ""
+		}
+		return obsidian.MatchPreview(vaultPath, matches[i], searchTerm, height)
+	}))
 	if err != nil {
 		return err
 	}
@@ -54,7 +62,7 @@ func SearchNotesContent(vault obsidian.VaultManager, note obsidian.NoteManager,
 	if useEditor {
 		filePath := filepath.Join(vaultPath, selectedMatch.FilePath)
 		fmt.Printf("Opening note: %s\n", selectedMatch.FilePath)
-		return obsidian.OpenInEditor(filePath)
+		return obsidian.OpenInEditorAt(filePath, selectedMatch.LineNumber, matchColumn(filePath, selectedMatch, searchTerm))
 	}
 	obsidianUri := uri.Construct(ObsOpenUrl, map[string]string{
 		"file":  selectedMatch.FilePath,
@@ -63,6 +71,28 @@ func SearchNotesContent(vault obsidian.VaultManager, note obsidian.NoteManager,
 	return uri.Execute(obsidianUri)
 }
 
+// matchColumn returns the column the search term starts at on the line of a
+// match, counted in characters, or 0 for a match on the file name.
+func matchColumn(filePath string, match obsidian.NoteMatch, searchTerm string) int {
+	if match.LineNumber <= 0 {
+		return 0
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return 0
+	}
+	lines := strings.Split(string(content), "\n")
+	if match.LineNumber > len(lines) {
+		return 0
+	}
+	line := lines[match.LineNumber-1]
+	index := strings.Index(strings.ToLower(line), strings.ToLower(searchTerm))
+	if index < 0 {
+		return 0
+	}
+	return utf8.RuneCountInString(line[:index]) + 1
+}
+
 func formatMatchesForDisplay(matches []obsidian.NoteMatch) []string {
 	maxPathLength := calculateMaxPathLength(matches)
 
@@ -3,6 +3,7 @@ package actions_test
 import (
 	"errors"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/Yakitrak/obsidian-cli/mocks"
@@ -79,6 +80,22 @@ func TestSearchNotes(t *testing.T) {
 		assert.Equal(t, err, uri.ExecuteErr)
 	})
 
+	t.Run("Search note by alias", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		uri := mocks.MockUriManager{}
+		note := mocks.MockNoteManager{}
+		err := os.WriteFile(filepath.Join(vault.VaultPath, "note2"), []byte("---\naliases: [Jane Doe]\n---\n"), 0644)
+		assert.NoError(t, err)
+		// The alias is listed after the three notes
+		fuzzyFinder := mocks.MockFuzzyFinder{SelectedIndex: 3}
+		// Act
+		err = actions.SearchNotes(&vault, &note, &uri, &fuzzyFinder, false)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "note2", uri.Params["file"])
+	})
+
 	t.Run("Successful search with editor flag", func(t *testing.T) {
 		// Set up mocks
 		vault := mocks.MockVaultOperator{
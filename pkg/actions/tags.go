@@ -0,0 +1,59 @@
+package actions
+
+import (
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+func ListTags(vault obsidian.VaultManager) ([]obsidian.TagCount, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	return obsidian.CountTags(vaultPath)
+}
+
+// RenameTags renames or merges tags across the vault, including the tags
+// nested under them, and returns the notes that were changed.
+func RenameTags(vault obsidian.VaultManager, from []string, to string) ([]string, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	return obsidian.RenameTags(vaultPath, from, to)
+}
+
+// AddTags adds tags to the frontmatter of notes, given by path, file name or
+// alias, and returns the notes that were changed.
+func AddTags(vault obsidian.VaultManager, noteNames []string, tags []string) ([]string, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	var notePaths []string
+	for _, noteName := range noteNames {
+		relPath, err := noteRelPath(vaultPath, noteName)
+		if err != nil {
+			return nil, err
+		}
+		notePaths = append(notePaths, relPath)
+	}
+	return obsidian.AddTags(vaultPath, notePaths, tags)
+}
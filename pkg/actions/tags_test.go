@@ -0,0 +1,75 @@
+package actions_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListTags(t *testing.T) {
+	t.Run("Counts tags across the vault", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		assert.NoError(t, os.WriteFile(filepath.Join(vault.VaultPath, "a.md"), []byte("---\ntags: [proj]\n---\n#Proj/alpha #todo\n"), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(vault.VaultPath, "b.md"), []byte("#todo #proj/Alpha\n"), 0644))
+		// Act
+		counts, err := actions.ListTags(&vault)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []obsidian.TagCount{
+			{Name: "proj", Count: 1},
+			{Name: "Proj/alpha", Count: 2},
+			{Name: "todo", Count: 2},
+		}, counts)
+	})
+
+	t.Run("Error in getting vault path", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", PathError: errors.New("path error")}
+		_, err := actions.ListTags(&vault)
+		assert.EqualError(t, err, "path error")
+	})
+}
+
+func TestRenameTags(t *testing.T) {
+	t.Run("Merges tags into one", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		notePath := filepath.Join(vault.VaultPath, "a.md")
+		assert.NoError(t, os.WriteFile(notePath, []byte("---\ntags: [todo, to-do]\n---\n#todo and #to-do\n"), 0644))
+		// Act
+		changed, err := actions.RenameTags(&vault, []string{"todo", "to-do"}, "task")
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.md"}, changed)
+		content, _ := os.ReadFile(notePath)
+		assert.Equal(t, "---\ntags: [task]\n---\n#task and #task\n", string(content))
+	})
+}
+
+func TestAddTags(t *testing.T) {
+	t.Run("Adds tags to notes by name and alias", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		assert.NoError(t, os.WriteFile(filepath.Join(vault.VaultPath, "a.md"), []byte("A\n"), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(vault.VaultPath, "P-1.md"), []byte("---\naliases: [Jane]\n---\n"), 0644))
+		// Act
+		changed, err := actions.AddTags(&vault, []string{"a", "Jane"}, []string{"person"})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.md", "P-1.md"}, changed)
+		content, _ := os.ReadFile(filepath.Join(vault.VaultPath, "P-1.md"))
+		assert.Equal(t, "---\naliases: [Jane]\ntags:\n  - person\n---\n", string(content))
+	})
+
+	t.Run("Unknown note", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		_, err := actions.AddTags(&vault, []string{"missing"}, []string{"person"})
+		assert.EqualError(t, err, obsidian.NoteDoesNotExistError)
+	})
+}
@@ -0,0 +1,107 @@
+package actions
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+func ListTasks(vault obsidian.VaultManager, filter obsidian.TaskFilter) ([]obsidian.Task, error) {
+	if !obsidian.ValidTaskStatus(filter.Status) {
+		return nil, errors.New(obsidian.InvalidTaskStatusError)
+	}
+
+	_, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := obsidian.FindTasks(vaultPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []obsidian.Task
+	for _, task := range tasks {
+		if filter.Matches(task) {
+			matches = append(matches, task)
+		}
+	}
+	return matches, nil
+}
+
+// CompleteTask checks off the task with the given ID, adding today's done
+// date. A recurring task gets its next occurrence inserted above it, as the
+// Tasks plugin does. A task that is already done is left as it is.
+func CompleteTask(vault obsidian.VaultManager, id string) (obsidian.Task, error) {
+	return updateTask(vault, id, func(task obsidian.Task) ([]string, error) {
+		if task.IsDone() {
+			return []string{task.Line}, nil
+		}
+		return completeTaskLines(task, time.Now())
+	})
+}
+
+// ToggleTask checks off an open task, or reopens a done or cancelled one.
+func ToggleTask(vault obsidian.VaultManager, id string) (obsidian.Task, error) {
+	return updateTask(vault, id, func(task obsidian.Task) ([]string, error) {
+		if task.IsOpen() {
+			return completeTaskLines(task, time.Now())
+		}
+		return []string{obsidian.ReopenTaskLine(task.Line)}, nil
+	})
+}
+
+// completeTaskLines returns the lines replacing a completed task, with the
+// task itself always last.
+func completeTaskLines(task obsidian.Task, now time.Time) ([]string, error) {
+	completed := obsidian.CompleteTaskLine(task.Line, now)
+	if task.Recurrence == "" {
+		return []string{completed}, nil
+	}
+	next, err := obsidian.NextTaskOccurrence(task, now)
+	if err != nil {
+		return nil, err
+	}
+	return []string{next, completed}, nil
+}
+
+func updateTask(vault obsidian.VaultManager, id string, update func(obsidian.Task) ([]string, error)) (obsidian.Task, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return obsidian.Task{}, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return obsidian.Task{}, err
+	}
+
+	task, err := obsidian.FindTaskByID(vaultPath, id)
+	if err != nil {
+		return obsidian.Task{}, err
+	}
+
+	lines, err := update(task)
+	if err != nil {
+		return obsidian.Task{}, err
+	}
+	if len(lines) != 1 || lines[0] != task.Line {
+		err = obsidian.ReplaceTaskLine(vaultPath, task, lines...)
+		if err != nil {
+			return obsidian.Task{}, err
+		}
+	}
+
+	updated, _ := obsidian.ParseTask(lines[len(lines)-1])
+	updated.FilePath = task.FilePath
+	updated.LineNumber = task.LineNumber + len(lines) - 1
+	updated.ID = obsidian.TaskID(updated.FilePath, updated.LineNumber)
+	return updated, nil
+}
@@ -0,0 +1,130 @@
+package actions_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTasksVault(t *testing.T) mocks.MockVaultOperator {
+	t.Helper()
+	notes := map[string]string{
+		"Inbox.md":             "- [ ] buy milk\n- [x] call bob ✅ 2024-01-02\n",
+		"Projects/Apollo.md":   "- [ ] launch #work 📅 2024-03-01\n",
+		".obsidian/ignored.md": "- [ ] hidden\n",
+	}
+	return mocks.MockVaultOperator{Name: "myVault", VaultPath: mocks.CreateVaultFiles(t, notes)}
+}
+
+func TestListTasks(t *testing.T) {
+	t.Run("Lists open tasks across the vault", func(t *testing.T) {
+		// Arrange
+		vault := createTasksVault(t)
+		// Act
+		tasks, err := actions.ListTasks(&vault, obsidian.TaskFilter{})
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, tasks, 2)
+		assert.Equal(t, "buy milk", tasks[0].Description)
+		assert.Equal(t, "launch #work", tasks[1].Description)
+	})
+
+	t.Run("Applies filters", func(t *testing.T) {
+		// Arrange
+		vault := createTasksVault(t)
+		// Act
+		tasks, err := actions.ListTasks(&vault, obsidian.TaskFilter{Tag: "work", DueBefore: time.Date(2024, 4, 1, 0, 0, 0, 0, time.Local)})
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, tasks, 1)
+		assert.Equal(t, filepath.Join("Projects", "Apollo.md"), tasks[0].FilePath)
+	})
+
+	t.Run("Invalid status", func(t *testing.T) {
+		vault := createTasksVault(t)
+		_, err := actions.ListTasks(&vault, obsidian.TaskFilter{Status: "later"})
+		assert.EqualError(t, err, obsidian.InvalidTaskStatusError)
+	})
+
+	t.Run("vault.DefaultName returns an error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{DefaultNameErr: errors.New("Failed to get vault name")}
+		_, err := actions.ListTasks(&vault, obsidian.TaskFilter{})
+		assert.Equal(t, vault.DefaultNameErr, err)
+	})
+}
+
+func TestCompleteTask(t *testing.T) {
+	t.Run("Checks off task in place", func(t *testing.T) {
+		// Arrange
+		vault := createTasksVault(t)
+		tasks, _ := actions.ListTasks(&vault, obsidian.TaskFilter{Note: "Inbox"})
+		// Act
+		task, err := actions.CompleteTask(&vault, tasks[0].ID)
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, task.IsDone())
+		content, _ := os.ReadFile(filepath.Join(vault.VaultPath, "Inbox.md"))
+		today := time.Now().Format(obsidian.DateLayout)
+		assert.Equal(t, "- [x] buy milk ✅ "+today+"\n- [x] call bob ✅ 2024-01-02\n", string(content))
+	})
+
+	t.Run("Unknown task ID", func(t *testing.T) {
+		vault := createTasksVault(t)
+		_, err := actions.CompleteTask(&vault, "0000000")
+		assert.EqualError(t, err, obsidian.TaskNotFoundError)
+	})
+}
+
+func TestToggleTask(t *testing.T) {
+	t.Run("Reopens done task", func(t *testing.T) {
+		// Arrange
+		vault := createTasksVault(t)
+		tasks, _ := actions.ListTasks(&vault, obsidian.TaskFilter{Status: obsidian.TaskStatusDone})
+		// Act
+		task, err := actions.ToggleTask(&vault, tasks[0].ID)
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, task.IsOpen())
+		content, _ := os.ReadFile(filepath.Join(vault.VaultPath, "Inbox.md"))
+		assert.Equal(t, "- [ ] buy milk\n- [ ] call bob\n", string(content))
+	})
+}
+
+func TestCompleteRecurringTask(t *testing.T) {
+	t.Run("Inserts the next occurrence above the completed task", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		notePath := filepath.Join(vault.VaultPath, "Chores.md")
+		assert.NoError(t, os.WriteFile(notePath, []byte("- [ ] Bins 🔁 every week 📅 2024-01-10\n"), 0644))
+		tasks, _ := actions.ListTasks(&vault, obsidian.TaskFilter{})
+		// Act
+		task, err := actions.CompleteTask(&vault, tasks[0].ID)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2, task.LineNumber)
+		content, _ := os.ReadFile(notePath)
+		today := time.Now().Format(obsidian.DateLayout)
+		assert.Equal(t, "- [ ] Bins 🔁 every week 📅 2024-01-17\n- [x] Bins 🔁 every week 📅 2024-01-10 ✅ "+today+"\n", string(content))
+	})
+
+	t.Run("Invalid recurrence rule leaves task untouched", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault", VaultPath: t.TempDir()}
+		notePath := filepath.Join(vault.VaultPath, "Chores.md")
+		assert.NoError(t, os.WriteFile(notePath, []byte("- [ ] Bins 🔁 every blue moon\n"), 0644))
+		tasks, _ := actions.ListTasks(&vault, obsidian.TaskFilter{})
+		// Act
+		_, err := actions.CompleteTask(&vault, tasks[0].ID)
+		// Assert
+		assert.EqualError(t, err, obsidian.InvalidRecurrenceError)
+		content, _ := os.ReadFile(notePath)
+		assert.Equal(t, "- [ ] Bins 🔁 every blue moon\n", string(content))
+	})
+}
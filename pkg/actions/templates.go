@@ -0,0 +1,90 @@
+package actions
+
+import (
+	"path/filepath"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+func ListTemplates(vault obsidian.VaultManager) ([]string, error) {
+	_, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	templatesConfig, err := obsidian.ReadTemplatesConfig(vaultPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return obsidian.ListTemplates(vaultPath, templatesConfig)
+}
+
+func PickTemplate(vault obsidian.VaultManager, fuzzyFinder obsidian.FuzzyFinderManager) (string, error) {
+	templates, err := ListTemplates(vault)
+	if err != nil {
+		return "", err
+	}
+
+	index, err := fuzzyFinder.Find(templates, func(i int) string {
+		return templates[i]
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return templates[index], nil
+}
+
+// renderNoteTemplate renders the named template from the Templates folder
+// with data, using the date and time formats from the Templates settings.
+// Custom variables missing from data.Variables are asked for with prompt when
+// it is set.
+func renderNoteTemplate(vaultPath string, templateName string, data obsidian.TemplateData, prompt func(name string) (string, error)) (string, error) {
+	templatesConfig, err := obsidian.ReadTemplatesConfig(vaultPath)
+	if err != nil {
+		return "", err
+	}
+
+	templates, err := obsidian.ListTemplates(vaultPath, templatesConfig)
+	if err != nil {
+		return "", err
+	}
+
+	templateName, err = obsidian.FindTemplate(templates, templateName)
+	if err != nil {
+		return "", err
+	}
+
+	template, err := obsidian.ReadTemplate(vaultPath, filepath.Join(templatesConfig.Folder, templateName))
+	if err != nil {
+		return "", err
+	}
+
+	variables := map[string]string{}
+	for name, value := range data.Variables {
+		variables[name] = value
+	}
+	if prompt != nil {
+		for _, name := range obsidian.TemplateVariables(template) {
+			if _, ok := variables[name]; ok {
+				continue
+			}
+			value, err := prompt(name)
+			if err != nil {
+				return "", err
+			}
+			variables[name] = value
+		}
+	}
+
+	data.Variables = variables
+	data.DateFormat = templatesConfig.DateFormat
+	data.TimeFormat = templatesConfig.TimeFormat
+	return obsidian.RenderTemplate(template, data), nil
+}
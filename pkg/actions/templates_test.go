@@ -0,0 +1,64 @@
+package actions_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTemplatesVault(t *testing.T, templates map[string]string) string {
+	t.Helper()
+	files := map[string]string{".obsidian/templates.json": `{"folder":"Templates"}`}
+	for name, content := range templates {
+		files[filepath.Join("Templates", name)] = content
+	}
+	return mocks.CreateVaultFiles(t, files)
+}
+
+func TestListTemplates(t *testing.T) {
+	t.Run("Successful list templates", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{VaultPath: createTemplatesVault(t, map[string]string{"Meeting.md": "", "Daily.md": ""})}
+		// Act
+		templates, err := actions.ListTemplates(&vault)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Daily", "Meeting"}, templates)
+	})
+
+	t.Run("vault.Path returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{PathError: errors.New("Failed to get vault path")}
+		// Act
+		_, err := actions.ListTemplates(&vault)
+		// Assert
+		assert.Equal(t, vault.PathError, err)
+	})
+}
+
+func TestPickTemplate(t *testing.T) {
+	t.Run("Successful pick template", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{VaultPath: createTemplatesVault(t, map[string]string{"Meeting.md": "", "Daily.md": ""})}
+		fuzzyFinder := mocks.MockFuzzyFinder{SelectedIndex: 1}
+		// Act
+		template, err := actions.PickTemplate(&vault, &fuzzyFinder)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Meeting", template)
+	})
+
+	t.Run("fuzzy finder returns an error", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{VaultPath: createTemplatesVault(t, map[string]string{"Meeting.md": ""})}
+		fuzzyFinder := mocks.MockFuzzyFinder{FindErr: errors.New("Fuzzy find error")}
+		// Act
+		_, err := actions.PickTemplate(&vault, &fuzzyFinder)
+		// Assert
+		assert.Equal(t, fuzzyFinder.FindErr, err)
+	})
+}
@@ -0,0 +1,27 @@
+package actions
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+)
+
+// LoadWorkspace switches Obsidian to a workspace saved with the Workspaces
+// core plugin, through the Advanced URI plugin.
+func LoadWorkspace(vault obsidian.VaultManager, uri obsidian.UriManager, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New(obsidian.InvalidWorkspaceError)
+	}
+
+	vaultName, err := vault.DefaultName()
+	if err != nil {
+		return err
+	}
+
+	return uri.Execute(uri.Construct(ObsAdvancedUrl, map[string]string{
+		"vault":     vaultName,
+		"workspace": name,
+	}))
+}
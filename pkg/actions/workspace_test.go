@@ -0,0 +1,37 @@
+package actions_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadWorkspace(t *testing.T) {
+	t.Run("Loads a workspace", func(t *testing.T) {
+		// Arrange
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		uri := mocks.MockUriManager{}
+		// Act
+		err := actions.LoadWorkspace(&vault, &uri, "Writing")
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, actions.ObsAdvancedUrl, uri.BaseUri)
+		assert.Equal(t, map[string]string{"vault": "myVault", "workspace": "Writing"}, uri.Params)
+	})
+
+	t.Run("Empty workspace name", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault"}
+		err := actions.LoadWorkspace(&vault, &mocks.MockUriManager{}, "")
+		assert.EqualError(t, err, obsidian.InvalidWorkspaceError)
+	})
+
+	t.Run("vault.DefaultName returns an error", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{DefaultNameErr: errors.New("Failed to get vault name")}
+		err := actions.LoadWorkspace(&vault, &mocks.MockUriManager{}, "Writing")
+		assert.EqualError(t, err, "Failed to get vault name")
+	})
+}
@@ -0,0 +1,538 @@
+// Package browse is the full-screen vault browser of the browse command: a
+// folder tree, the notes of the selected folder, a rendered preview of the
+// selected note and the notes linking to it.
+package browse
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/actions"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/gdamore/tcell/v2"
+)
+
+type pane int
+
+const (
+	folderPane pane = iota
+	notePane
+	backlinkPane
+)
+
+// Browser holds the state of the vault browser. Paths are vault relative and
+// use forward slashes, with "" for the vault root.
+type Browser struct {
+	vault     obsidian.VaultManager
+	note      obsidian.NoteManager
+	uri       obsidian.UriManager
+	vaultName string
+	vaultPath string
+
+	folders   []string
+	notes     []string
+	backlinks map[string][]string
+
+	folder   int
+	selected int
+	backlink int
+	focus    pane
+	scroll   int
+	status   string
+	input    *input
+	preview  preview
+
+	// edit opens a note in the editor. Run replaces it with one that hands
+	// the terminal over to the editor meanwhile.
+	edit func(notePath string) error
+}
+
+// input is a question asked on the status line, such as the new name of a
+// note. done is called with the answer unless it is cancelled with Esc.
+type input struct {
+	label string
+	text  []rune
+	done  func(answer string)
+}
+
+// New loads the folders, notes and links of the vault.
+func New(vault obsidian.VaultManager, note obsidian.NoteManager, uri obsidian.UriManager) (*Browser, error) {
+	vaultName, err := vault.DefaultName()
+	if err != nil {
+		return nil, err
+	}
+	vaultPath, err := vault.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Browser{
+		vault:     vault,
+		note:      note,
+		uri:       uri,
+		vaultName: vaultName,
+		vaultPath: vaultPath,
+		focus:     notePane,
+		status:    helpText,
+		edit:      obsidian.OpenInEditor,
+	}
+	return b, b.Reload()
+}
+
+// Reload rereads the vault after a change, keeping the selected folder and
+// note where they still exist.
+func (b *Browser) Reload() error {
+	folder, note := b.Folder(), b.Note()
+
+	var folders []string
+	err := filepath.WalkDir(b.vaultPath, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") && filePath != b.vaultPath {
+			return filepath.SkipDir
+		}
+		relPath, err := filepath.Rel(b.vaultPath, filePath)
+		if err != nil {
+			return err
+		}
+		if relPath == "." {
+			relPath = ""
+		}
+		folders = append(folders, filepath.ToSlash(relPath))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(folders, func(i, j int) bool {
+		return strings.ToLower(folders[i]) < strings.ToLower(folders[j])
+	})
+	b.folders = folders
+
+	backlinks, err := findBacklinks(b.vaultPath)
+	if err != nil {
+		return err
+	}
+	b.backlinks = backlinks
+
+	b.folder = indexOf(b.folders, folder)
+	b.loadNotes()
+	b.selectNote(note)
+	return nil
+}
+
+func (b *Browser) loadNotes() {
+	b.notes = nil
+	b.selected, b.backlink, b.scroll = 0, 0, 0
+	entries, err := os.ReadDir(filepath.Join(b.vaultPath, filepath.FromSlash(b.Folder())))
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || filepath.Ext(entry.Name()) != ".md" {
+			continue
+		}
+		b.notes = append(b.notes, path.Join(b.Folder(), entry.Name()))
+	}
+	sort.Slice(b.notes, func(i, j int) bool {
+		return strings.ToLower(b.notes[i]) < strings.ToLower(b.notes[j])
+	})
+}
+
+// findBacklinks maps each note to the notes linking to it.
+func findBacklinks(vaultPath string) (map[string][]string, error) {
+	index, err := obsidian.BuildNoteIndex(vaultPath)
+	if err != nil {
+		return nil, err
+	}
+	backlinks := map[string][]string{}
+	err = obsidian.WalkNotes(vaultPath, func(relPath string, content []byte) error {
+		source := filepath.ToSlash(relPath)
+		seen := map[string]bool{}
+		for _, target := range obsidian.NoteLinks(string(content)) {
+			file, ok := index.Resolve(source, target)
+			if !ok || file == source || seen[file] {
+				continue
+			}
+			seen[file] = true
+			backlinks[file] = append(backlinks[file], source)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, sources := range backlinks {
+		sort.Strings(sources)
+	}
+	return backlinks, nil
+}
+
+// Folder returns the selected folder.
+func (b *Browser) Folder() string {
+	if b.folder < len(b.folders) {
+		return b.folders[b.folder]
+	}
+	return ""
+}
+
+// Note returns the selected note, or "" if the folder has no notes.
+func (b *Browser) Note() string {
+	if b.selected < len(b.notes) {
+		return b.notes[b.selected]
+	}
+	return ""
+}
+
+// Backlinks returns the notes linking to the selected note.
+func (b *Browser) Backlinks() []string {
+	return b.backlinks[b.Note()]
+}
+
+func (b *Browser) selectNote(note string) {
+	if i := indexOf(b.notes, note); i < len(b.notes) && b.notes[i] == note {
+		b.selected = i
+	}
+}
+
+// goToNote selects a note anywhere in the vault, along with its folder.
+func (b *Browser) goToNote(note string) {
+	folder := path.Dir(note)
+	if folder == "." {
+		folder = ""
+	}
+	b.folder = indexOf(b.folders, folder)
+	b.loadNotes()
+	b.selectNote(note)
+	b.focus = notePane
+}
+
+func indexOf(items []string, item string) int {
+	for i, candidate := range items {
+		if candidate == item {
+			return i
+		}
+	}
+	return 0
+}
+
+// HandleKey acts on a key press and reports whether the browser should keep
+// running.
+func (b *Browser) HandleKey(ev *tcell.EventKey) bool {
+	if b.input != nil {
+		b.handleInput(ev)
+		return true
+	}
+
+	switch ev.Key() {
+	case tcell.KeyCtrlC, tcell.KeyEscape:
+		return false
+	case tcell.KeyUp:
+		b.moveCursor(-1)
+	case tcell.KeyDown:
+		b.moveCursor(1)
+	case tcell.KeyTab, tcell.KeyRight:
+		b.cycleFocus(1)
+	case tcell.KeyBacktab, tcell.KeyLeft:
+		b.cycleFocus(-1)
+	case tcell.KeyPgDn, tcell.KeyCtrlD:
+		b.scroll += 10
+	case tcell.KeyPgUp, tcell.KeyCtrlU:
+		b.scroll -= 10
+		if b.scroll < 0 {
+			b.scroll = 0
+		}
+	case tcell.KeyEnter:
+		b.activate()
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'q':
+			return false
+		case 'k':
+			b.moveCursor(-1)
+		case 'j':
+			b.moveCursor(1)
+		case 'l':
+			b.cycleFocus(1)
+		case 'h':
+			b.cycleFocus(-1)
+		case 'o':
+			b.open()
+		case 'e':
+			b.editNote()
+		case 'r':
+			b.rename()
+		case 'm':
+			b.move()
+		case 'd':
+			b.delete()
+		case 'n':
+			b.create()
+		case '?':
+			b.status = helpText
+		}
+	}
+	return true
+}
+
+func (b *Browser) handleInput(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyCtrlC:
+		b.input = nil
+		b.status = "Cancelled"
+	case tcell.KeyEnter:
+		in := b.input
+		b.input = nil
+		in.done(strings.TrimSpace(string(in.text)))
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if len(b.input.text) > 0 {
+			b.input.text = b.input.text[:len(b.input.text)-1]
+		}
+	case tcell.KeyCtrlU:
+		b.input.text = nil
+	case tcell.KeyRune:
+		b.input.text = append(b.input.text, ev.Rune())
+	}
+}
+
+func (b *Browser) moveCursor(delta int) {
+	switch b.focus {
+	case folderPane:
+		folder := clamp(b.folder+delta, len(b.folders))
+		if folder != b.folder {
+			b.folder = folder
+			b.loadNotes()
+		}
+	case notePane:
+		selected := clamp(b.selected+delta, len(b.notes))
+		if selected != b.selected {
+			b.selected, b.backlink, b.scroll = selected, 0, 0
+		}
+	case backlinkPane:
+		b.backlink = clamp(b.backlink+delta, len(b.Backlinks()))
+	}
+}
+
+func clamp(i int, length int) int {
+	if i >= length {
+		i = length - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	return i
+}
+
+func (b *Browser) cycleFocus(delta int) {
+	b.focus = pane((int(b.focus) + delta + 3) % 3)
+}
+
+// activate opens the folder, note or backlink under the cursor.
+func (b *Browser) activate() {
+	switch b.focus {
+	case folderPane:
+		b.focus = notePane
+	case notePane:
+		b.open()
+	case backlinkPane:
+		if backlinks := b.Backlinks(); b.backlink < len(backlinks) {
+			b.goToNote(backlinks[b.backlink])
+		}
+	}
+}
+
+func (b *Browser) open() {
+	note := b.Note()
+	if note == "" {
+		return
+	}
+	b.run("Opened "+note, func() error {
+		return actions.OpenNote(b.vault, b.uri, actions.OpenParams{NoteName: note})
+	})
+}
+
+func (b *Browser) editNote() {
+	note := b.Note()
+	if note == "" {
+		return
+	}
+	// The editor needs the real stdout, so this does not go through run.
+	err := b.edit(filepath.Join(b.vaultPath, filepath.FromSlash(note)))
+	if reloadErr := b.Reload(); err == nil {
+		err = reloadErr
+	}
+	b.preview = preview{}
+	if err != nil {
+		b.status = err.Error()
+		return
+	}
+	b.status = "Edited " + note
+}
+
+func (b *Browser) rename() {
+	note := b.Note()
+	if note == "" {
+		return
+	}
+	b.ask("Rename to: ", obsidian.RemoveMdSuffix(path.Base(note)), func(name string) {
+		if name == "" || strings.Contains(name, "/") {
+			b.status = "A new name without slashes is needed, use m to move the note"
+			return
+		}
+		b.moveTo(note, path.Join(path.Dir(note), name))
+	})
+}
+
+func (b *Browser) move() {
+	note := b.Note()
+	if note == "" {
+		return
+	}
+	b.ask("Move to: ", obsidian.RemoveMdSuffix(note), func(newPath string) {
+		if newPath == "" {
+			return
+		}
+		b.moveTo(note, strings.Trim(newPath, "/"))
+	})
+}
+
+func (b *Browser) moveTo(note string, newPath string) {
+	newPath = obsidian.AddMdSuffix(newPath)
+	err := os.MkdirAll(filepath.Join(b.vaultPath, filepath.FromSlash(path.Dir(newPath))), 0755)
+	if err != nil {
+		b.status = obsidian.VaultWriteError
+		return
+	}
+	ok := b.run("Moved "+note+" to "+newPath, func() error {
+		return actions.MoveNote(b.vault, b.note, b.uri, actions.MoveParams{
+			CurrentNoteName: obsidian.RemoveMdSuffix(note),
+			NewNoteName:     obsidian.RemoveMdSuffix(newPath),
+		})
+	})
+	if ok {
+		b.goToNote(newPath)
+	}
+}
+
+func (b *Browser) delete() {
+	note := b.Note()
+	if note == "" {
+		return
+	}
+	b.ask("Delete "+note+"? (y/n) ", "", func(answer string) {
+		if !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+			b.status = "Not deleted"
+			return
+		}
+		b.run("Deleted "+note, func() error {
+			return actions.DeleteNote(b.vault, b.note, actions.DeleteParams{NotePath: note})
+		})
+	})
+}
+
+// create makes an empty note in the selected folder and opens it in the
+// editor. The file is written directly rather than through Obsidian, which
+// is often not running on the machine the browser is used on.
+func (b *Browser) create() {
+	b.ask("New note: ", "", func(name string) {
+		if name == "" {
+			return
+		}
+		notePath := obsidian.AddMdSuffix(path.Join(b.Folder(), strings.Trim(name, "/")))
+		fullPath := filepath.Join(b.vaultPath, filepath.FromSlash(notePath))
+		ok := b.run("Created "+notePath, func() error {
+			if _, err := os.Stat(fullPath); err == nil {
+				return errors.New(NoteExistsError)
+			}
+			err := os.MkdirAll(filepath.Dir(fullPath), 0755)
+			if err != nil {
+				return errors.New(obsidian.VaultWriteError)
+			}
+			err = os.WriteFile(fullPath, nil, 0644)
+			if err != nil {
+				return errors.New(obsidian.VaultWriteError)
+			}
+			return nil
+		})
+		if ok {
+			b.goToNote(notePath)
+			b.editNote()
+		}
+	})
+}
+
+func (b *Browser) ask(label string, text string, done func(answer string)) {
+	b.input = &input{label: label, text: []rune(text), done: done}
+}
+
+// run calls an action and reloads the vault, showing the outcome on the
+// status line. The actions report what they did on stdout, which would
+// garble the screen, so their output is dropped.
+func (b *Browser) run(success string, action func() error) bool {
+	err := quietly(action)
+	if reloadErr := b.Reload(); err == nil {
+		err = reloadErr
+	}
+	if err != nil {
+		b.status = err.Error()
+		return false
+	}
+	b.status = success
+	return true
+}
+
+func quietly(fn func() error) error {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return fn()
+	}
+	defer devNull.Close()
+	stdout := os.Stdout
+	os.Stdout = devNull
+	defer func() { os.Stdout = stdout }()
+	return fn()
+}
+
+// Run shows the browser on screen until it is quit. A panic is returned as
+// an error so that the caller can still give the terminal back.
+func (b *Browser) Run(screen tcell.Screen) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s: %v", BrowserError, r)
+		}
+	}()
+
+	b.edit = func(notePath string) error {
+		err := screen.Suspend()
+		if err != nil {
+			return err
+		}
+		defer screen.Resume()
+		return obsidian.OpenInEditor(notePath)
+	}
+
+	for {
+		b.Draw(screen)
+		screen.Show()
+		switch ev := screen.PollEvent().(type) {
+		case nil:
+			return nil
+		case *tcell.EventResize:
+			screen.Sync()
+		case *tcell.EventKey:
+			if !b.HandleKey(ev) {
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package browse_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/browse"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func browseVault(t *testing.T) mocks.MockVaultOperator {
+	notes := map[string]string{
+		"Home.md":            "# Home\n\nSee [[Projects/Apollo]].\n",
+		"Inbox.md":           "Call about [[Apollo]].\n",
+		"Projects/Apollo.md": "# Apollo\n\nTo the moon.\n",
+		".obsidian/app.md":   "hidden",
+	}
+	return mocks.MockVaultOperator{Name: "myVault", VaultPath: mocks.CreateVaultFiles(t, notes)}
+}
+
+func newBrowser(t *testing.T, vault *mocks.MockVaultOperator, uri *mocks.MockUriManager) *browse.Browser {
+	b, err := browse.New(vault, &obsidian.Note{}, uri)
+	assert.NoError(t, err)
+	return b
+}
+
+func press(b *browse.Browser, keys ...interface{}) bool {
+	running := true
+	for _, key := range keys {
+		switch k := key.(type) {
+		case rune:
+			running = b.HandleKey(tcell.NewEventKey(tcell.KeyRune, k, tcell.ModNone))
+		case string:
+			for _, r := range k {
+				running = b.HandleKey(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+			}
+		case tcell.Key:
+			running = b.HandleKey(tcell.NewEventKey(k, 0, tcell.ModNone))
+		}
+	}
+	return running
+}
+
+func TestBrowser(t *testing.T) {
+	t.Run("Lists the notes and backlinks of the vault root", func(t *testing.T) {
+		// Arrange
+		vault := browseVault(t)
+		// Act
+		b := newBrowser(t, &vault, &mocks.MockUriManager{})
+		// Assert
+		assert.Equal(t, "", b.Folder())
+		assert.Equal(t, "Home.md", b.Note())
+		assert.Empty(t, b.Backlinks())
+	})
+
+	t.Run("Moves between folders and notes", func(t *testing.T) {
+		// Arrange
+		vault := browseVault(t)
+		b := newBrowser(t, &vault, &mocks.MockUriManager{})
+		// Act
+		press(b, tcell.KeyBacktab, 'j', tcell.KeyEnter)
+		// Assert
+		assert.Equal(t, "Projects", b.Folder())
+		assert.Equal(t, "Projects/Apollo.md", b.Note())
+		assert.Equal(t, []string{"Home.md", "Inbox.md"}, b.Backlinks())
+	})
+
+	t.Run("Goes to a backlink", func(t *testing.T) {
+		// Arrange
+		vault := browseVault(t)
+		b := newBrowser(t, &vault, &mocks.MockUriManager{})
+		press(b, tcell.KeyBacktab, 'j', tcell.KeyEnter)
+		// Act
+		press(b, tcell.KeyTab, 'j', tcell.KeyEnter)
+		// Assert
+		assert.Equal(t, "", b.Folder())
+		assert.Equal(t, "Inbox.md", b.Note())
+	})
+
+	t.Run("Opens the note in Obsidian", func(t *testing.T) {
+		// Arrange
+		vault := browseVault(t)
+		uri := mocks.MockUriManager{}
+		b := newBrowser(t, &vault, &uri)
+		// Act
+		press(b, 'j', 'o')
+		// Assert
+		assert.Equal(t, "Inbox.md", uri.Params["file"])
+	})
+
+	t.Run("Renames the note and updates links", func(t *testing.T) {
+		// Arrange
+		vault := browseVault(t)
+		b := newBrowser(t, &vault, &mocks.MockUriManager{})
+		press(b, tcell.KeyBacktab, 'j', tcell.KeyEnter)
+		// Act
+		press(b, 'r', tcell.KeyCtrlU, "Artemis", tcell.KeyEnter)
+		// Assert
+		assert.Equal(t, "Projects/Artemis.md", b.Note())
+		assert.NoFileExists(t, filepath.Join(vault.VaultPath, "Projects", "Apollo.md"))
+		home, err := os.ReadFile(filepath.Join(vault.VaultPath, "Home.md"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(home), "[[Projects/Artemis]]")
+	})
+
+	t.Run("Moves the note to a new folder", func(t *testing.T) {
+		// Arrange
+		vault := browseVault(t)
+		b := newBrowser(t, &vault, &mocks.MockUriManager{})
+		// Act
+		press(b, 'j', 'm', tcell.KeyCtrlU, "Archive/Inbox", tcell.KeyEnter)
+		// Assert
+		assert.Equal(t, "Archive", b.Folder())
+		assert.Equal(t, "Archive/Inbox.md", b.Note())
+		assert.FileExists(t, filepath.Join(vault.VaultPath, "Archive", "Inbox.md"))
+	})
+
+	t.Run("Deletes the note once confirmed", func(t *testing.T) {
+		// Arrange
+		vault := browseVault(t)
+		b := newBrowser(t, &vault, &mocks.MockUriManager{})
+		// Act
+		press(b, 'd', 'n', tcell.KeyEnter)
+		press(b, 'd', 'y', tcell.KeyEnter)
+		// Assert
+		assert.NoFileExists(t, filepath.Join(vault.VaultPath, "Home.md"))
+		assert.Equal(t, "Inbox.md", b.Note())
+	})
+
+	t.Run("Does not create a note over an existing one", func(t *testing.T) {
+		// Arrange
+		vault := browseVault(t)
+		b := newBrowser(t, &vault, &mocks.MockUriManager{})
+		// Act
+		press(b, 'n', "Inbox", tcell.KeyEnter)
+		// Assert
+		assert.Equal(t, "Home.md", b.Note())
+		content, err := os.ReadFile(filepath.Join(vault.VaultPath, "Inbox.md"))
+		assert.NoError(t, err)
+		assert.Equal(t, "Call about [[Apollo]].\n", string(content))
+	})
+
+	t.Run("Cancels a question with Esc and quits with q", func(t *testing.T) {
+		// Arrange
+		vault := browseVault(t)
+		b := newBrowser(t, &vault, &mocks.MockUriManager{})
+		// Act
+		stillRunning := press(b, 'r', tcell.KeyEscape)
+		quit := press(b, 'q')
+		// Assert
+		assert.True(t, stillRunning)
+		assert.False(t, quit)
+		assert.FileExists(t, filepath.Join(vault.VaultPath, "Home.md"))
+	})
+
+	t.Run("Draws the panes", func(t *testing.T) {
+		// Arrange
+		vault := browseVault(t)
+		b := newBrowser(t, &vault, &mocks.MockUriManager{})
+		screen := tcell.NewSimulationScreen("")
+		assert.NoError(t, screen.Init())
+		defer screen.Fini()
+		screen.SetSize(100, 20)
+		// Act
+		b.Draw(screen)
+		screen.Show()
+		// Assert
+		cells, width, _ := screen.GetContents()
+		var text strings.Builder
+		for i, cell := range cells {
+			if i > 0 && i%width == 0 {
+				text.WriteString("\n")
+			}
+			text.WriteString(string(cell.Runes))
+		}
+		assert.Contains(t, text.String(), "myVault/")
+		assert.Contains(t, text.String(), "Projects/")
+		assert.Contains(t, text.String(), "Inbox")
+		assert.Contains(t, text.String(), "See")
+		assert.Contains(t, text.String(), "Backlinks (0)")
+	})
+
+	t.Run("Draws code blocks on small screens", func(t *testing.T) {
+		// Arrange
+		vault := browseVault(t)
+		err := os.WriteFile(filepath.Join(vault.VaultPath, "Code.md"), []byte("```javascript\nlet x = 1\n```\n"), 0644)
+		assert.NoError(t, err)
+		b := newBrowser(t, &vault, &mocks.MockUriManager{})
+		screen := tcell.NewSimulationScreen("")
+		assert.NoError(t, screen.Init())
+		defer screen.Fini()
+		// Act & Assert
+		for width := 10; width <= 60; width++ {
+			screen.SetSize(width, 8)
+			assert.NotPanics(t, func() { b.Draw(screen) }, "width %d", width)
+		}
+	})
+
+	t.Run("Runs until quit", func(t *testing.T) {
+		// Arrange
+		vault := browseVault(t)
+		b := newBrowser(t, &vault, &mocks.MockUriManager{})
+		screen := tcell.NewSimulationScreen("")
+		assert.NoError(t, screen.Init())
+		defer screen.Fini()
+		screen.SetSize(80, 20)
+		screen.InjectKey(tcell.KeyRune, 'j', tcell.ModNone)
+		screen.InjectKey(tcell.KeyRune, 'q', tcell.ModNone)
+		// Act
+		err := b.Run(screen)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Inbox.md", b.Note())
+	})
+
+	t.Run("Error in getting vault path", func(t *testing.T) {
+		vault := mocks.MockVaultOperator{Name: "myVault", PathError: os.ErrNotExist}
+		_, err := browse.New(&vault, &obsidian.Note{}, &mocks.MockUriManager{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,6 @@
+package browse
+
+const (
+	NoteExistsError = "A note with that name already exists"
+	BrowserError    = "The browser stopped on an unexpected error"
+)
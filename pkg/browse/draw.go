@@ -0,0 +1,228 @@
+package browse
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/Yakitrak/obsidian-cli/pkg/render"
+	"github.com/gdamore/tcell/v2"
+	"github.com/ktr0731/go-ansisgr"
+	"github.com/mattn/go-runewidth"
+)
+
+const helpText = "enter/o open  e edit  n new  r rename  m move  d delete  tab switch pane  pgup/pgdn scroll  q quit"
+
+// minPreviewWidth is the narrowest pane the preview is rendered in.
+const minPreviewWidth = 12
+
+// preview caches the rendered lines of the selected note for a width.
+type preview struct {
+	note  string
+	width int
+	lines []string
+}
+
+var (
+	titleStyle    = tcell.StyleDefault.Bold(true)
+	focusedTitle  = tcell.StyleDefault.Bold(true).Reverse(true)
+	cursorStyle   = tcell.StyleDefault.Reverse(true)
+	unfocusedItem = tcell.StyleDefault.Underline(true)
+	borderStyle   = tcell.StyleDefault.Foreground(tcell.ColorGray)
+	statusStyle   = tcell.StyleDefault.Foreground(tcell.ColorGray)
+)
+
+// Draw lays out the folders, notes, preview and backlinks side by side, with
+// the status line at the bottom.
+func (b *Browser) Draw(screen tcell.Screen) {
+	screen.Clear()
+	width, height := screen.Size()
+	if width < 20 || height < 5 {
+		return
+	}
+
+	folderWidth := width / 5
+	noteWidth := width / 4
+	previewX := folderWidth + noteWidth + 2
+	previewWidth := width - previewX
+	bodyHeight := height - 1
+	previewHeight := bodyHeight * 2 / 3
+
+	folderLabels := make([]string, len(b.folders))
+	for i, folder := range b.folders {
+		folderLabels[i] = b.folderLabel(folder)
+	}
+	noteLabels := make([]string, len(b.notes))
+	for i, note := range b.notes {
+		noteLabels[i] = obsidian.RemoveMdSuffix(path.Base(note))
+	}
+	backlinks := b.Backlinks()
+	backlinkLabels := make([]string, len(backlinks))
+	for i, backlink := range backlinks {
+		backlinkLabels[i] = obsidian.RemoveMdSuffix(backlink)
+	}
+
+	b.drawList(screen, 0, 0, folderWidth, bodyHeight, "Folders", folderLabels, b.folder, b.focus == folderPane)
+	drawVertical(screen, folderWidth, bodyHeight)
+	b.drawList(screen, folderWidth+1, 0, noteWidth, bodyHeight, "Notes", noteLabels, b.selected, b.focus == notePane)
+	drawVertical(screen, folderWidth+noteWidth+1, bodyHeight)
+	b.drawPreview(screen, previewX, 0, previewWidth, previewHeight)
+	drawHorizontal(screen, previewX, previewHeight, previewWidth)
+	title := "Backlinks (" + strconv.Itoa(len(backlinks)) + ")"
+	b.drawList(screen, previewX, previewHeight+1, previewWidth, bodyHeight-previewHeight-1, title, backlinkLabels, b.backlink, b.focus == backlinkPane)
+	b.drawStatus(screen, height-1, width)
+}
+
+func (b *Browser) folderLabel(folder string) string {
+	if folder == "" {
+		return b.vaultName + "/"
+	}
+	depth := strings.Count(folder, "/") + 1
+	return strings.Repeat("  ", depth) + path.Base(folder) + "/"
+}
+
+// drawList draws a titled list, scrolled so the selected item shows.
+func (b *Browser) drawList(screen tcell.Screen, x int, y int, width int, height int, title string, items []string, selected int, focused bool) {
+	style := titleStyle
+	if focused {
+		style = focusedTitle
+	}
+	drawText(screen, x, y, width, " "+title+" ", style)
+
+	rows := height - 1
+	offset := 0
+	if selected >= rows {
+		offset = selected - rows + 1
+	}
+	for i := offset; i < len(items) && i-offset < rows; i++ {
+		style := tcell.StyleDefault
+		if i == selected {
+			style = unfocusedItem
+			if focused {
+				style = cursorStyle
+			}
+		}
+		drawText(screen, x, y+1+i-offset, width, " "+items[i], style)
+	}
+}
+
+func (b *Browser) drawPreview(screen tcell.Screen, x int, y int, width int, height int) {
+	note := b.Note()
+	if note == "" {
+		drawText(screen, x, y, width, " Preview ", titleStyle)
+		return
+	}
+	drawText(screen, x, y, width, " "+obsidian.RemoveMdSuffix(note)+" ", titleStyle)
+	if width-2 < minPreviewWidth {
+		drawText(screen, x+1, y+1, width-2, "Too narrow", statusStyle)
+		return
+	}
+
+	lines := b.previewLines(note, width-2)
+	if b.scroll > len(lines)-1 {
+		b.scroll = len(lines) - 1
+	}
+	if b.scroll < 0 {
+		b.scroll = 0
+	}
+	for i := 0; i < height-1 && b.scroll+i < len(lines); i++ {
+		drawANSI(screen, x+1, y+1+i, width-2, lines[b.scroll+i])
+	}
+}
+
+func (b *Browser) previewLines(note string, width int) []string {
+	if b.preview.note == note && b.preview.width == width {
+		return b.preview.lines
+	}
+	content, err := os.ReadFile(filepath.Join(b.vaultPath, filepath.FromSlash(note)))
+	lines := []string{}
+	if err == nil {
+		lines = strings.Split(render.Markdown(string(content), width), "\n")
+	}
+	b.preview = preview{note: note, width: width, lines: lines}
+	return lines
+}
+
+func (b *Browser) drawStatus(screen tcell.Screen, y int, width int) {
+	if b.input != nil {
+		text := b.input.label + string(b.input.text)
+		drawText(screen, 0, y, width, text, tcell.StyleDefault)
+		screen.ShowCursor(runewidth.StringWidth(text), y)
+		return
+	}
+	screen.HideCursor()
+	drawText(screen, 0, y, width, b.status, statusStyle)
+}
+
+func drawVertical(screen tcell.Screen, x int, height int) {
+	for y := 0; y < height; y++ {
+		screen.SetContent(x, y, '│', nil, borderStyle)
+	}
+}
+
+func drawHorizontal(screen tcell.Screen, x int, y int, width int) {
+	for i := 0; i < width; i++ {
+		screen.SetContent(x+i, y, '─', nil, borderStyle)
+	}
+}
+
+// drawText writes text from x, cutting it off at width columns.
+func drawText(screen tcell.Screen, x int, y int, width int, text string, style tcell.Style) {
+	column := 0
+	for _, r := range text {
+		w := runewidth.RuneWidth(r)
+		if column+w > width {
+			return
+		}
+		screen.SetContent(x+column, y, r, nil, style)
+		column += w
+	}
+	// Styled rows such as the cursor fill the whole width.
+	if style != tcell.StyleDefault {
+		for ; column < width; column++ {
+			screen.SetContent(x+column, y, ' ', nil, style)
+		}
+	}
+}
+
+// drawANSI writes a line of rendered Markdown, turning its ANSI styles into
+// tcell styles.
+func drawANSI(screen tcell.Screen, x int, y int, width int, line string) {
+	column := 0
+	iterator := ansisgr.NewIterator(line)
+	for {
+		r, sgr, ok := iterator.Next()
+		if !ok {
+			return
+		}
+		w := runewidth.RuneWidth(r)
+		if column+w > width {
+			return
+		}
+		screen.SetContent(x+column, y, r, nil, ansiStyle(sgr))
+		column += w
+	}
+}
+
+func ansiStyle(sgr ansisgr.Style) tcell.Style {
+	style := tcell.StyleDefault.
+		Bold(sgr.Bold()).
+		Dim(sgr.Dim()).
+		Italic(sgr.Italic()).
+		Underline(sgr.Underline()).
+		Reverse(sgr.Reverse()).
+		StrikeThrough(sgr.Strikethrough())
+	if color, ok := sgr.Foreground(); ok && color.Mode() == ansisgr.Mode16 {
+		// 30-37 are the normal colors and 90-97 the bright ones.
+		value := color.Value()
+		if value >= 90 {
+			style = style.Foreground(tcell.PaletteColor(value - 90 + 8))
+		} else {
+			style = style.Foreground(tcell.PaletteColor(value - 30))
+		}
+	}
+	return style
+}
@@ -6,4 +6,7 @@ const (
 	ObsidianConfigFile                      = "obsidian.json"
 	ObsidianCLIConfigDirectory              = "obsidian-cli"
 	ObsidianCLIConfigFile                   = "preferences.json"
+	VaultConfigDirectory                    = ".obsidian"
+	DailyNotesConfigFile                    = "daily-notes.json"
+	TemplatesConfigFile                     = "templates.json"
 )
@@ -0,0 +1,189 @@
+package graph
+
+import (
+	"math"
+	"sort"
+)
+
+// Score is a value computed for a node, such as its number of backlinks or its
+// PageRank.
+type Score struct {
+	ID    string
+	Value float64
+}
+
+// Backlinks counts the links to each node and returns the nodes sorted from
+// most to least linked. Nodes nothing links to are left out.
+func (g Graph) Backlinks() []Score {
+	counts := map[string]float64{}
+	for _, edge := range g.Edges {
+		counts[edge.Target]++
+	}
+	scores := make([]Score, 0, len(counts))
+	for id, count := range counts {
+		scores = append(scores, Score{ID: id, Value: count})
+	}
+	sortScores(scores)
+	return scores
+}
+
+// PageRank scores every node by the chance of reaching it by following links
+// at random, with a damping factor of 0.85. The scores add up to 1.
+func (g Graph) PageRank() []Score {
+	const damping = 0.85
+	n := float64(len(g.Nodes))
+	if n == 0 {
+		return []Score{}
+	}
+
+	outLinks := map[string][]string{}
+	for _, edge := range g.Edges {
+		if edge.Source != edge.Target {
+			outLinks[edge.Source] = append(outLinks[edge.Source], edge.Target)
+		}
+	}
+	rank := map[string]float64{}
+	for _, node := range g.Nodes {
+		rank[node.ID] = 1 / n
+	}
+	for iteration := 0; iteration < 100; iteration++ {
+		// Nodes without links spread their rank over the whole graph.
+		dangling := 0.0
+		for _, node := range g.Nodes {
+			if len(outLinks[node.ID]) == 0 {
+				dangling += rank[node.ID]
+			}
+		}
+		next := map[string]float64{}
+		for _, node := range g.Nodes {
+			next[node.ID] = (1-damping)/n + damping*dangling/n
+		}
+		for _, node := range g.Nodes {
+			for _, target := range outLinks[node.ID] {
+				next[target] += damping * rank[node.ID] / float64(len(outLinks[node.ID]))
+			}
+		}
+
+		change := 0.0
+		for id, value := range next {
+			change += math.Abs(value - rank[id])
+		}
+		rank = next
+		if change < 1e-9 {
+			break
+		}
+	}
+
+	scores := make([]Score, 0, len(g.Nodes))
+	for _, node := range g.Nodes {
+		scores = append(scores, Score{ID: node.ID, Value: rank[node.ID]})
+	}
+	sortScores(scores)
+	return scores
+}
+
+func sortScores(scores []Score) {
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Value != scores[j].Value {
+			return scores[i].Value > scores[j].Value
+		}
+		return scores[i].ID < scores[j].ID
+	})
+}
+
+// Components splits the graph into the groups of nodes connected by links in
+// either direction. The largest groups come first and the IDs in each group
+// are sorted.
+func (g Graph) Components() [][]string {
+	neighbors := g.neighbors()
+	seen := map[string]bool{}
+	components := [][]string{}
+	for _, node := range g.Nodes {
+		if seen[node.ID] {
+			continue
+		}
+		seen[node.ID] = true
+		component := []string{}
+		queue := []string{node.ID}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			component = append(component, current)
+			for _, next := range neighbors[current] {
+				if !seen[next] {
+					seen[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		sort.Strings(component)
+		components = append(components, component)
+	}
+	sort.SliceStable(components, func(i, j int) bool {
+		return len(components[i]) > len(components[j])
+	})
+	return components
+}
+
+// DeadEnds returns the notes that do not link anywhere. Attachments are left
+// out as they cannot link.
+func (g Graph) DeadEnds() []string {
+	linking := map[string]bool{}
+	for _, edge := range g.Edges {
+		linking[edge.Source] = true
+	}
+	deadEnds := []string{}
+	for _, node := range g.Nodes {
+		if !node.Attachment && !linking[node.ID] {
+			deadEnds = append(deadEnds, node.ID)
+		}
+	}
+	return deadEnds
+}
+
+// ShortestPath returns the shortest chain of links from one node to another,
+// both included. Unless directed is set, links are followed backwards too.
+// Among chains of the same length the one through the first IDs in sort
+// order wins.
+func (g Graph) ShortestPath(from string, to string, directed bool) ([]string, bool) {
+	if _, ok := g.Node(from); !ok {
+		return nil, false
+	}
+	if _, ok := g.Node(to); !ok {
+		return nil, false
+	}
+
+	neighbors := g.neighbors()
+	if directed {
+		neighbors = map[string][]string{}
+		for _, edge := range g.Edges {
+			neighbors[edge.Source] = append(neighbors[edge.Source], edge.Target)
+		}
+		for _, ids := range neighbors {
+			sort.Strings(ids)
+		}
+	}
+
+	previous := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 && queue[0] != to {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range neighbors[current] {
+			if _, seen := previous[next]; !seen {
+				previous[next] = current
+				queue = append(queue, next)
+			}
+		}
+	}
+	if _, ok := previous[to]; !ok {
+		return nil, false
+	}
+
+	path := []string{to}
+	for id := to; id != from; {
+		id = previous[id]
+		path = append([]string{id}, path...)
+	}
+	return path, true
+}
@@ -0,0 +1,83 @@
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBacklinks(t *testing.T) {
+	// Act
+	scores := chain().Backlinks()
+	// Assert
+	assert.Equal(t, []graph.Score{{ID: "b.md", Value: 2}, {ID: "d.md", Value: 1}}, scores)
+}
+
+func TestPageRank(t *testing.T) {
+	t.Run("Ranks the most linked note first", func(t *testing.T) {
+		// Act
+		scores := chain().PageRank()
+		// Assert
+		assert.Equal(t, "b.md", scores[0].ID)
+		total := 0.0
+		for _, score := range scores {
+			total += score.Value
+		}
+		assert.InDelta(t, 1, total, 1e-6)
+	})
+
+	t.Run("Empty graph", func(t *testing.T) {
+		assert.Empty(t, graph.Graph{}.PageRank())
+	})
+}
+
+func TestComponents(t *testing.T) {
+	// Arrange
+	g := chain()
+	g.Nodes = append(g.Nodes, graph.Node{ID: "e.md"}, graph.Node{ID: "f.md"})
+	g.Edges = append(g.Edges, graph.Edge{Source: "f.md", Target: "e.md"})
+	// Act
+	components := g.Components()
+	// Assert
+	assert.Equal(t, [][]string{{"a.md", "b.md", "c.md", "d.md"}, {"e.md", "f.md"}}, components)
+}
+
+func TestDeadEnds(t *testing.T) {
+	// Arrange
+	g := chain()
+	g.Nodes = append(g.Nodes, graph.Node{ID: "pic.png", Attachment: true})
+	// Act
+	deadEnds := g.DeadEnds()
+	// Assert
+	assert.Equal(t, []string{"b.md", "d.md"}, deadEnds)
+}
+
+func TestShortestPath(t *testing.T) {
+	t.Run("Follows backlinks", func(t *testing.T) {
+		// Act
+		path, ok := chain().ShortestPath("a.md", "d.md", false)
+		// Assert
+		assert.True(t, ok)
+		assert.Equal(t, []string{"a.md", "b.md", "c.md", "d.md"}, path)
+	})
+
+	t.Run("Directed only follows links forwards", func(t *testing.T) {
+		_, ok := chain().ShortestPath("a.md", "d.md", true)
+		assert.False(t, ok)
+		path, ok := chain().ShortestPath("c.md", "d.md", true)
+		assert.True(t, ok)
+		assert.Equal(t, []string{"c.md", "d.md"}, path)
+	})
+
+	t.Run("Path to itself", func(t *testing.T) {
+		path, ok := chain().ShortestPath("a.md", "a.md", true)
+		assert.True(t, ok)
+		assert.Equal(t, []string{"a.md"}, path)
+	})
+
+	t.Run("Unknown node", func(t *testing.T) {
+		_, ok := chain().ShortestPath("a.md", "z.md", false)
+		assert.False(t, ok)
+	})
+}
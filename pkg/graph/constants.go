@@ -0,0 +1,6 @@
+package graph
+
+const (
+	UnknownFormatError = "Unknown graph format, please use dot, graphml, json or mermaid"
+	NoPathError        = "No chain of links connects the two notes"
+)
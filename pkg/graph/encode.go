@@ -0,0 +1,188 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FormatDOT     = "dot"
+	FormatGraphML = "graphml"
+	FormatJSON    = "json"
+	FormatMermaid = "mermaid"
+)
+
+// ValidFormat reports whether Encode can write the format.
+func ValidFormat(format string) bool {
+	switch format {
+	case FormatDOT, FormatGraphML, FormatJSON, FormatMermaid:
+		return true
+	}
+	return false
+}
+
+// Encode writes the graph to w in the given format.
+func Encode(w io.Writer, g Graph, format string) error {
+	switch format {
+	case FormatDOT:
+		return encodeDOT(w, g)
+	case FormatGraphML:
+		return encodeGraphML(w, g)
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(g)
+	case FormatMermaid:
+		return encodeMermaid(w, g)
+	}
+	return errors.New(UnknownFormatError)
+}
+
+// encodeDOT writes the graph for Graphviz. Tags and properties become node
+// attributes.
+func encodeDOT(w io.Writer, g Graph) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "digraph %s {\n", dotQuote(g.Name))
+	for _, node := range g.Nodes {
+		attributes := []string{"label=" + dotQuote(node.Label)}
+		if len(node.Tags) > 0 {
+			attributes = append(attributes, "tags="+dotQuote(strings.Join(node.Tags, ", ")))
+		}
+		if node.Attachment {
+			attributes = append(attributes, "shape=note")
+		}
+		for _, key := range sortedKeys(node.Properties) {
+			attributes = append(attributes, dotQuote(key)+"="+dotQuote(PropertyString(node.Properties[key])))
+		}
+		fmt.Fprintf(b, "  %s [%s];\n", dotQuote(node.ID), strings.Join(attributes, ", "))
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(b, "  %s -> %s;\n", dotQuote(edge.Source), dotQuote(edge.Target))
+	}
+	b.WriteString("}\n")
+	return b.Flush()
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// encodeGraphML writes the graph for yEd, Gephi and other tools reading
+// GraphML. Every property name gets a key of its own.
+func encodeGraphML(w io.Writer, g Graph) error {
+	var propertyNames []string
+	seen := map[string]bool{}
+	for _, node := range g.Nodes {
+		for key := range node.Properties {
+			if !seen[key] {
+				seen[key] = true
+				propertyNames = append(propertyNames, key)
+			}
+		}
+	}
+	sort.Strings(propertyNames)
+	propertyKeys := map[string]string{}
+
+	b := bufio.NewWriter(w)
+	b.WriteString(xml.Header)
+	b.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	b.WriteString(`  <key id="label" for="node" attr.name="label" attr.type="string"/>` + "\n")
+	b.WriteString(`  <key id="tags" for="node" attr.name="tags" attr.type="string"/>` + "\n")
+	b.WriteString(`  <key id="attachment" for="node" attr.name="attachment" attr.type="boolean"/>` + "\n")
+	for i, name := range propertyNames {
+		propertyKeys[name] = "p" + strconv.Itoa(i)
+		fmt.Fprintf(b, "  <key id=%q for=\"node\" attr.name=\"%s\" attr.type=\"string\"/>\n", propertyKeys[name], xmlEscape(name))
+	}
+	fmt.Fprintf(b, "  <graph id=\"%s\" edgedefault=\"directed\">\n", xmlEscape(g.Name))
+	for _, node := range g.Nodes {
+		fmt.Fprintf(b, "    <node id=\"%s\">\n", xmlEscape(node.ID))
+		fmt.Fprintf(b, "      <data key=\"label\">%s</data>\n", xmlEscape(node.Label))
+		if len(node.Tags) > 0 {
+			fmt.Fprintf(b, "      <data key=\"tags\">%s</data>\n", xmlEscape(strings.Join(node.Tags, ", ")))
+		}
+		if node.Attachment {
+			b.WriteString("      <data key=\"attachment\">true</data>\n")
+		}
+		for _, key := range sortedKeys(node.Properties) {
+			fmt.Fprintf(b, "      <data key=%q>%s</data>\n", propertyKeys[key], xmlEscape(PropertyString(node.Properties[key])))
+		}
+		b.WriteString("    </node>\n")
+	}
+	for i, edge := range g.Edges {
+		fmt.Fprintf(b, "    <edge id=\"e%d\" source=\"%s\" target=\"%s\"/>\n", i, xmlEscape(edge.Source), xmlEscape(edge.Target))
+	}
+	b.WriteString("  </graph>\n</graphml>\n")
+	return b.Flush()
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// encodeMermaid writes a Mermaid flowchart, which Obsidian and GitHub render
+// in ```mermaid code blocks. Nodes get short IDs as Mermaid IDs cannot hold
+// paths.
+func encodeMermaid(w io.Writer, g Graph) error {
+	b := bufio.NewWriter(w)
+	b.WriteString("graph LR\n")
+	ids := map[string]string{}
+	for i, node := range g.Nodes {
+		ids[node.ID] = "n" + strconv.Itoa(i)
+		label := strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(node.Label)
+		if node.Attachment {
+			fmt.Fprintf(b, "  %s[/\"%s\"/]\n", ids[node.ID], label)
+			continue
+		}
+		fmt.Fprintf(b, "  %s[\"%s\"]\n", ids[node.ID], label)
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(b, "  %s --> %s\n", ids[edge.Source], ids[edge.Target])
+	}
+	return b.Flush()
+}
+
+// PropertyString formats a frontmatter property value as text. Lists are
+// joined with commas and dates are written as YYYY-MM-DD.
+func PropertyString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		if v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 {
+			return v.Format("2006-01-02")
+		}
+		return v.Format("2006-01-02T15:04:05")
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = PropertyString(item)
+		}
+		return strings.Join(items, ", ")
+	case map[string]interface{}:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(encoded)
+	}
+	return fmt.Sprint(value)
+}
+
+func sortedKeys(properties map[string]interface{}) []string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
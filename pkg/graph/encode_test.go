@@ -0,0 +1,79 @@
+package graph_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+func sample() graph.Graph {
+	return graph.Graph{
+		Name: "vault",
+		Nodes: []graph.Node{
+			{ID: "a.md", Label: "a", Tags: []string{"proj"}, Properties: map[string]interface{}{"status": "draft"}},
+			{ID: "img/b.png", Label: "b.png", Attachment: true},
+		},
+		Edges: []graph.Edge{{Source: "a.md", Target: "img/b.png"}},
+	}
+}
+
+func TestEncode(t *testing.T) {
+	t.Run("DOT", func(t *testing.T) {
+		// Arrange
+		var b bytes.Buffer
+		// Act
+		err := graph.Encode(&b, sample(), graph.FormatDOT)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "digraph \"vault\" {\n"+
+			"  \"a.md\" [label=\"a\", tags=\"proj\", \"status\"=\"draft\"];\n"+
+			"  \"img/b.png\" [label=\"b.png\", shape=note];\n"+
+			"  \"a.md\" -> \"img/b.png\";\n"+
+			"}\n", b.String())
+	})
+
+	t.Run("GraphML", func(t *testing.T) {
+		var b bytes.Buffer
+		err := graph.Encode(&b, sample(), graph.FormatGraphML)
+		assert.NoError(t, err)
+		assert.Contains(t, b.String(), `<key id="p0" for="node" attr.name="status" attr.type="string"/>`)
+		assert.Contains(t, b.String(), `<data key="p0">draft</data>`)
+		assert.Contains(t, b.String(), `<edge id="e0" source="a.md" target="img/b.png"/>`)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var b bytes.Buffer
+		err := graph.Encode(&b, sample(), graph.FormatJSON)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"name": "vault",
+			"nodes": [
+				{"id": "a.md", "label": "a", "tags": ["proj"], "properties": {"status": "draft"}},
+				{"id": "img/b.png", "label": "b.png", "attachment": true}
+			],
+			"edges": [{"source": "a.md", "target": "img/b.png"}]
+		}`, b.String())
+	})
+
+	t.Run("Mermaid", func(t *testing.T) {
+		var b bytes.Buffer
+		err := graph.Encode(&b, sample(), graph.FormatMermaid)
+		assert.NoError(t, err)
+		assert.Equal(t, "graph LR\n  n0[\"a\"]\n  n1[/\"b.png\"/]\n  n0 --> n1\n", b.String())
+	})
+
+	t.Run("Unknown format", func(t *testing.T) {
+		var b bytes.Buffer
+		err := graph.Encode(&b, sample(), "svg")
+		assert.EqualError(t, err, graph.UnknownFormatError)
+	})
+}
+
+func TestPropertyString(t *testing.T) {
+	assert.Equal(t, "2024-01-10", graph.PropertyString(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "a, 2", graph.PropertyString([]interface{}{"a", 2}))
+	assert.Equal(t, "", graph.PropertyString(nil))
+}
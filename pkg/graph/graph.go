@@ -0,0 +1,104 @@
+// Package graph holds the link graph of a vault and writes it in the file
+// formats of graph tools.
+package graph
+
+import "sort"
+
+// Node is a note, or an attachment linked from a note. Its ID is the vault
+// relative path of the file.
+type Node struct {
+	ID         string                 `json:"id"`
+	Label      string                 `json:"label"`
+	Tags       []string               `json:"tags,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+	Attachment bool                   `json:"attachment,omitempty"`
+}
+
+// Edge is a link from the Source node to the Target node.
+type Edge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// Graph is a directed graph of notes and the links between them. Several
+// links between the same two notes make a single edge.
+type Graph struct {
+	Name  string `json:"name,omitempty"`
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// Node returns the node with the given ID.
+func (g Graph) Node(id string) (Node, bool) {
+	for _, node := range g.Nodes {
+		if node.ID == id {
+			return node, true
+		}
+	}
+	return Node{}, false
+}
+
+// Filter returns the graph made of the nodes keep returns true for and the
+// edges between them.
+func (g Graph) Filter(keep func(Node) bool) Graph {
+	kept := map[string]bool{}
+	result := Graph{Name: g.Name, Nodes: []Node{}, Edges: []Edge{}}
+	for _, node := range g.Nodes {
+		if keep(node) {
+			kept[node.ID] = true
+			result.Nodes = append(result.Nodes, node)
+		}
+	}
+	for _, edge := range g.Edges {
+		if kept[edge.Source] && kept[edge.Target] {
+			result.Edges = append(result.Edges, edge)
+		}
+	}
+	return result
+}
+
+// Neighborhood returns the part of the graph within depth links of the node
+// with the given ID, following links in both directions.
+func (g Graph) Neighborhood(id string, depth int) Graph {
+	neighbors := g.neighbors()
+	distance := map[string]int{id: 0}
+	queue := []string{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if distance[current] == depth {
+			continue
+		}
+		for _, next := range neighbors[current] {
+			if _, seen := distance[next]; !seen {
+				distance[next] = distance[current] + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+	return g.Filter(func(node Node) bool {
+		_, ok := distance[node.ID]
+		return ok
+	})
+}
+
+// neighbors returns the IDs of the nodes linked to or from each node, sorted.
+func (g Graph) neighbors() map[string][]string {
+	seen := map[[2]string]bool{}
+	neighbors := map[string][]string{}
+	add := func(from string, to string) {
+		if from == to || seen[[2]string{from, to}] {
+			return
+		}
+		seen[[2]string{from, to}] = true
+		neighbors[from] = append(neighbors[from], to)
+	}
+	for _, edge := range g.Edges {
+		add(edge.Source, edge.Target)
+		add(edge.Target, edge.Source)
+	}
+	for _, ids := range neighbors {
+		sort.Strings(ids)
+	}
+	return neighbors
+}
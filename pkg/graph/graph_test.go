@@ -0,0 +1,44 @@
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+func chain() graph.Graph {
+	return graph.Graph{
+		Nodes: []graph.Node{{ID: "a.md"}, {ID: "b.md"}, {ID: "c.md"}, {ID: "d.md"}},
+		Edges: []graph.Edge{
+			{Source: "a.md", Target: "b.md"},
+			{Source: "c.md", Target: "b.md"},
+			{Source: "c.md", Target: "d.md"},
+		},
+	}
+}
+
+func TestFilter(t *testing.T) {
+	t.Run("Keeps edges between kept nodes", func(t *testing.T) {
+		// Act
+		g := chain().Filter(func(node graph.Node) bool { return node.ID != "b.md" })
+		// Assert
+		assert.Equal(t, []graph.Node{{ID: "a.md"}, {ID: "c.md"}, {ID: "d.md"}}, g.Nodes)
+		assert.Equal(t, []graph.Edge{{Source: "c.md", Target: "d.md"}}, g.Edges)
+	})
+}
+
+func TestNeighborhood(t *testing.T) {
+	t.Run("Follows links in both directions", func(t *testing.T) {
+		// Act
+		g := chain().Neighborhood("b.md", 1)
+		// Assert
+		assert.Equal(t, []graph.Node{{ID: "a.md"}, {ID: "b.md"}, {ID: "c.md"}}, g.Nodes)
+		assert.Len(t, g.Edges, 2)
+	})
+
+	t.Run("Depth limits the distance", func(t *testing.T) {
+		assert.Len(t, chain().Neighborhood("a.md", 2).Nodes, 3)
+		assert.Len(t, chain().Neighborhood("a.md", 3).Nodes, 4)
+	})
+}
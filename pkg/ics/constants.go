@@ -0,0 +1,6 @@
+package ics
+
+const (
+	ParseError = "Failed to parse iCalendar file, please check it is a valid .ics file"
+	RRuleError = "Unsupported recurrence rule in iCalendar file"
+)
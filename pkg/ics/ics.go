@@ -0,0 +1,180 @@
+// Package ics reads and writes iCalendar (RFC 5545) files.
+package ics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405Z"
+	maxLineOctets  = 75
+)
+
+const (
+	StatusNeedsAction = "NEEDS-ACTION"
+	StatusCompleted   = "COMPLETED"
+	StatusCancelled   = "CANCELLED"
+)
+
+// Calendar is a VCALENDAR with its events and to-dos.
+type Calendar struct {
+	ProdID string
+	Name   string
+	Events []Event
+	Todos  []Todo
+}
+
+// Event is a VEVENT. All-day events use dates only and an exclusive End.
+// The recurrence and attendee fields are only read by Decode.
+type Event struct {
+	UID          string
+	Stamp        time.Time
+	Summary      string
+	Description  string
+	Location     string
+	URL          string
+	Start        time.Time
+	End          time.Time
+	AllDay       bool
+	Status       string
+	Organizer    string
+	Attendees    []string
+	RRule        string
+	ExDates      []time.Time
+	RecurrenceID time.Time
+}
+
+// Todo is a VTODO. Due and Start are dates.
+type Todo struct {
+	UID         string
+	Stamp       time.Time
+	Summary     string
+	Description string
+	URL         string
+	Start       time.Time
+	Due         time.Time
+	Completed   time.Time
+	Status      string
+	Priority    int
+}
+
+// Encode writes the calendar with CRLF line endings, folding long lines.
+func (c Calendar) Encode(w io.Writer) error {
+	e := encoder{w: bufio.NewWriter(w)}
+	e.line("BEGIN:VCALENDAR")
+	e.line("VERSION:2.0")
+	e.line("PRODID:" + c.ProdID)
+	e.line("CALSCALE:GREGORIAN")
+	if c.Name != "" {
+		e.text("X-WR-CALNAME", c.Name)
+	}
+
+	for _, event := range c.Events {
+		e.line("BEGIN:VEVENT")
+		e.line("UID:" + event.UID)
+		e.line("DTSTAMP:" + event.Stamp.UTC().Format(dateTimeLayout))
+		if event.AllDay {
+			e.line("DTSTART;VALUE=DATE:" + event.Start.Format(dateLayout))
+			if !event.End.IsZero() {
+				e.line("DTEND;VALUE=DATE:" + event.End.Format(dateLayout))
+			}
+		} else {
+			e.line("DTSTART:" + event.Start.UTC().Format(dateTimeLayout))
+			if !event.End.IsZero() {
+				e.line("DTEND:" + event.End.UTC().Format(dateTimeLayout))
+			}
+		}
+		e.text("SUMMARY", event.Summary)
+		e.text("DESCRIPTION", event.Description)
+		e.text("LOCATION", event.Location)
+		if event.URL != "" {
+			e.line("URL:" + event.URL)
+		}
+		e.line("END:VEVENT")
+	}
+
+	for _, todo := range c.Todos {
+		e.line("BEGIN:VTODO")
+		e.line("UID:" + todo.UID)
+		e.line("DTSTAMP:" + todo.Stamp.UTC().Format(dateTimeLayout))
+		if !todo.Start.IsZero() {
+			e.line("DTSTART;VALUE=DATE:" + todo.Start.Format(dateLayout))
+		}
+		if !todo.Due.IsZero() {
+			e.line("DUE;VALUE=DATE:" + todo.Due.Format(dateLayout))
+		}
+		e.text("SUMMARY", todo.Summary)
+		e.text("DESCRIPTION", todo.Description)
+		if todo.URL != "" {
+			e.line("URL:" + todo.URL)
+		}
+		if todo.Status != "" {
+			e.line("STATUS:" + todo.Status)
+		}
+		if !todo.Completed.IsZero() {
+			e.line("COMPLETED:" + todo.Completed.UTC().Format(dateTimeLayout))
+		}
+		if todo.Priority > 0 {
+			e.line(fmt.Sprintf("PRIORITY:%d", todo.Priority))
+		}
+		e.line("END:VTODO")
+	}
+
+	e.line("END:VCALENDAR")
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+// text writes a TEXT property, escaped and skipped when empty.
+func (e *encoder) text(name string, value string) {
+	if value != "" {
+		e.line(name + ":" + EscapeText(value))
+	}
+}
+
+// line writes a content line, folded at 75 octets without splitting UTF-8
+// characters. Continuation lines start with a space.
+func (e *encoder) line(line string) {
+	if e.err != nil {
+		return
+	}
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		_, e.err = e.w.WriteString(line[:cut] + "\r\n ")
+		if e.err != nil {
+			return
+		}
+		line = line[cut:]
+		// The leading space of a continuation line counts towards its length.
+		limit = maxLineOctets - 1
+	}
+	_, e.err = e.w.WriteString(line + "\r\n")
+}
+
+// EscapeText escapes a TEXT property value.
+func EscapeText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
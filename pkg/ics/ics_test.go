@@ -0,0 +1,92 @@
+package ics_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/ics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeText(t *testing.T) {
+	assert.Equal(t, `a\, b\; c\\d\ne`, ics.EscapeText("a, b; c\\d\ne"))
+}
+
+func TestCalendarEncode(t *testing.T) {
+	stamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("Encodes events and to-dos with CRLF", func(t *testing.T) {
+		// Arrange
+		calendar := ics.Calendar{
+			ProdID: "-//test//EN",
+			Events: []ics.Event{{
+				UID:     "event@test",
+				Stamp:   stamp,
+				Summary: "Offsite, day 1",
+				Start:   time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+				End:     time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC),
+				AllDay:  true,
+			}},
+			Todos: []ics.Todo{{
+				UID:      "todo@test",
+				Stamp:    stamp,
+				Summary:  "Pay rent",
+				Due:      time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				Status:   ics.StatusNeedsAction,
+				Priority: 3,
+			}},
+		}
+		var out bytes.Buffer
+		// Act
+		err := calendar.Encode(&out)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"PRODID:-//test//EN",
+			"CALSCALE:GREGORIAN",
+			"BEGIN:VEVENT",
+			"UID:event@test",
+			"DTSTAMP:20240102T030405Z",
+			"DTSTART;VALUE=DATE:20240110",
+			"DTEND;VALUE=DATE:20240111",
+			"SUMMARY:Offsite\\, day 1",
+			"END:VEVENT",
+			"BEGIN:VTODO",
+			"UID:todo@test",
+			"DTSTAMP:20240102T030405Z",
+			"DUE;VALUE=DATE:20240201",
+			"SUMMARY:Pay rent",
+			"STATUS:NEEDS-ACTION",
+			"PRIORITY:3",
+			"END:VTODO",
+			"END:VCALENDAR",
+			"",
+		}, "\r\n"), out.String())
+	})
+
+	t.Run("Folds long lines without splitting characters", func(t *testing.T) {
+		// Arrange
+		calendar := ics.Calendar{Todos: []ics.Todo{{Summary: strings.Repeat("é", 100)}}}
+		var out bytes.Buffer
+		// Act
+		err := calendar.Encode(&out)
+		// Assert
+		assert.NoError(t, err)
+		var summary string
+		for _, line := range strings.Split(out.String(), "\r\n") {
+			assert.LessOrEqual(t, len(line), 75)
+			if strings.HasPrefix(line, "SUMMARY:") {
+				summary = line
+			} else if summary != "" && strings.HasPrefix(line, " ") {
+				summary += line[1:]
+			} else if summary != "" {
+				break
+			}
+		}
+		assert.Equal(t, "SUMMARY:"+strings.Repeat("é", 100), summary)
+	})
+}
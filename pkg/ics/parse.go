@@ -0,0 +1,335 @@
+package ics
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// property is a content line, e.g. DTSTART;TZID=Europe/London:20240110T090000.
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Decode reads the events of an iCalendar file. Recurring events keep their
+// rule; use EventsOn to expand them.
+func Decode(r io.Reader) (Calendar, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return Calendar{}, errors.New(ParseError)
+	}
+
+	var calendar Calendar
+	var stack []string
+	var event *Event
+	var duration string
+	for _, line := range lines {
+		prop, ok := parseProperty(line)
+		if !ok {
+			continue
+		}
+		switch prop.name {
+		case "BEGIN":
+			stack = append(stack, strings.ToUpper(prop.value))
+			if len(stack) == 2 && stack[1] == "VEVENT" {
+				event, duration = &Event{}, ""
+			}
+			continue
+		case "END":
+			if len(stack) == 0 {
+				return Calendar{}, errors.New(ParseError)
+			}
+			if len(stack) == 2 && stack[1] == "VEVENT" && event != nil {
+				if duration != "" {
+					days, length, err := parseDuration(duration)
+					if err != nil {
+						return Calendar{}, err
+					}
+					event.End = event.Start.AddDate(0, 0, days).Add(length)
+				}
+				if event.End.IsZero() {
+					event.End = event.Start
+					if event.AllDay {
+						event.End = event.Start.AddDate(0, 0, 1)
+					}
+				}
+				calendar.Events = append(calendar.Events, *event)
+				event = nil
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		if len(stack) == 1 && prop.name == "X-WR-CALNAME" {
+			calendar.Name = unescapeText(prop.value)
+		}
+		// Properties of nested components such as VALARM are ignored.
+		if event == nil || len(stack) != 2 {
+			continue
+		}
+		if prop.name == "DURATION" {
+			duration = prop.value
+			continue
+		}
+		err = setEventProperty(event, prop)
+		if err != nil {
+			return Calendar{}, err
+		}
+	}
+	if len(stack) != 0 {
+		return Calendar{}, errors.New(ParseError)
+	}
+	return calendar, nil
+}
+
+func setEventProperty(event *Event, prop property) error {
+	var err error
+	switch prop.name {
+	case "UID":
+		event.UID = prop.value
+	case "SUMMARY":
+		event.Summary = unescapeText(prop.value)
+	case "DESCRIPTION":
+		event.Description = unescapeText(prop.value)
+	case "LOCATION":
+		event.Location = unescapeText(prop.value)
+	case "URL":
+		event.URL = prop.value
+	case "STATUS":
+		event.Status = strings.ToUpper(prop.value)
+	case "DTSTART":
+		event.Start, event.AllDay, err = parseDateTime(prop)
+	case "DTEND":
+		event.End, _, err = parseDateTime(prop)
+	case "DTSTAMP":
+		event.Stamp, _, err = parseDateTime(prop)
+	case "RRULE":
+		event.RRule = prop.value
+	case "EXDATE":
+		for _, value := range strings.Split(prop.value, ",") {
+			date, _, err := parseDateTime(property{name: prop.name, params: prop.params, value: value})
+			if err != nil {
+				return err
+			}
+			event.ExDates = append(event.ExDates, date)
+		}
+	case "RECURRENCE-ID":
+		event.RecurrenceID, _, err = parseDateTime(prop)
+	case "ORGANIZER":
+		event.Organizer = personName(prop)
+	case "ATTENDEE":
+		event.Attendees = append(event.Attendees, personName(prop))
+	}
+	return err
+}
+
+// unfoldLines splits the file into content lines, joining folded lines.
+func unfoldLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// parseProperty splits a content line into name, parameters and value,
+// respecting quoted parameter values that may contain : and ;.
+func parseProperty(line string) (property, bool) {
+	prop := property{params: map[string]string{}}
+	inQuotes := false
+	start := 0
+	var parts []string
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case ';', ':':
+			if inQuotes {
+				continue
+			}
+			parts = append(parts, line[start:i])
+			start = i + 1
+			if line[i] == ':' {
+				prop.value = line[i+1:]
+				prop.name = strings.ToUpper(parts[0])
+				for _, param := range parts[1:] {
+					if key, value, ok := strings.Cut(param, "="); ok {
+						prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+					}
+				}
+				return prop, prop.name != ""
+			}
+		}
+	}
+	return property{}, false
+}
+
+// parseDateTime parses a DATE or DATE-TIME value. UTC times end in Z, other
+// times are in their TZID or, failing that, local time. Dates are returned as
+// local midnight and flagged as all day.
+func parseDateTime(prop property) (time.Time, bool, error) {
+	value := strings.TrimSpace(prop.value)
+	if prop.params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		date, err := time.ParseInLocation("20060102", value, time.Local)
+		if err != nil {
+			return time.Time{}, false, errors.New(ParseError)
+		}
+		return date, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		date, err := time.Parse(dateTimeLayout, value)
+		if err != nil {
+			return time.Time{}, false, errors.New(ParseError)
+		}
+		return date, false, nil
+	}
+
+	location := time.Local
+	if tzid := prop.params["TZID"]; tzid != "" {
+		if loaded, err := time.LoadLocation(strings.TrimPrefix(tzid, "/")); err == nil {
+			location = loaded
+		}
+	}
+	date, err := time.ParseInLocation("20060102T150405", value, location)
+	if err != nil {
+		return time.Time{}, false, errors.New(ParseError)
+	}
+	return date, false, nil
+}
+
+var durationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration parses a DURATION value into days and a time duration, kept
+// apart so that days follow the calendar across daylight saving changes.
+func parseDuration(value string) (int, time.Duration, error) {
+	match := durationPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, 0, errors.New(ParseError)
+	}
+	number := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+	days := number(match[2])*7 + number(match[3])
+	duration := time.Duration(number(match[4]))*time.Hour +
+		time.Duration(number(match[5]))*time.Minute +
+		time.Duration(number(match[6]))*time.Second
+	if match[1] == "-" {
+		return -days, -duration, nil
+	}
+	return days, duration, nil
+}
+
+// personName returns the common name of an ATTENDEE or ORGANIZER, or their
+// email address when there is none.
+func personName(prop property) string {
+	if name := strings.TrimSpace(prop.params["CN"]); name != "" {
+		return name
+	}
+	value := prop.value
+	if strings.HasPrefix(strings.ToLower(value), "mailto:") {
+		value = value[len("mailto:"):]
+	}
+	return value
+}
+
+func unescapeText(value string) string {
+	return strings.NewReplacer(
+		`\\`, `\`,
+		`\;`, ";",
+		`\,`, ",",
+		`\n`, "\n",
+		`\N`, "\n",
+	).Replace(value)
+}
+
+// EventsOn returns the events taking place on the local day of date, with
+// recurring events expanded to the matching occurrence. Cancelled events and
+// occurrences are left out. The events are sorted by start time.
+func EventsOn(calendar Calendar, date time.Time) []Event {
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	// Modified occurrences replace the occurrence they were split from.
+	overridden := map[string]bool{}
+	for _, event := range calendar.Events {
+		if !event.RecurrenceID.IsZero() {
+			overridden[event.UID+"@"+strconv.FormatInt(event.RecurrenceID.Unix(), 10)] = true
+		}
+	}
+
+	var events []Event
+	for _, event := range calendar.Events {
+		if event.Status == StatusCancelled {
+			continue
+		}
+		if event.RRule == "" || !event.RecurrenceID.IsZero() {
+			if overlaps(event, dayStart, dayEnd) {
+				events = append(events, event)
+			}
+			continue
+		}
+
+		rule, err := ParseRRule(event.RRule)
+		if err != nil {
+			// An event we cannot expand still counts on its first day.
+			if overlaps(event, dayStart, dayEnd) {
+				events = append(events, event)
+			}
+			continue
+		}
+		length := event.End.Sub(event.Start)
+		for _, start := range rule.Occurrences(event.Start, dayEnd) {
+			occurrence := event
+			occurrence.Start = start
+			occurrence.End = start.Add(length)
+			if event.AllDay {
+				occurrence.End = start.AddDate(0, 0, int(length.Hours()/24+0.5))
+			}
+			if overridden[event.UID+"@"+strconv.FormatInt(start.Unix(), 10)] || isExcluded(event, start) {
+				continue
+			}
+			if overlaps(occurrence, dayStart, dayEnd) {
+				events = append(events, occurrence)
+			}
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Start.Before(events[j].Start)
+	})
+	return events
+}
+
+func overlaps(event Event, dayStart time.Time, dayEnd time.Time) bool {
+	if event.End.After(event.Start) {
+		return event.Start.Before(dayEnd) && event.End.After(dayStart)
+	}
+	return !event.Start.Before(dayStart) && event.Start.Before(dayEnd)
+}
+
+func isExcluded(event Event, start time.Time) bool {
+	for _, exDate := range event.ExDates {
+		if exDate.Equal(start) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package ics_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/ics"
+	"github.com/stretchr/testify/assert"
+)
+
+func decode(t *testing.T, lines ...string) ics.Calendar {
+	t.Helper()
+	content := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" + strings.Join(lines, "\r\n") + "\r\nEND:VCALENDAR\r\n"
+	calendar, err := ics.Decode(strings.NewReader(content))
+	assert.NoError(t, err)
+	return calendar
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("Reads event properties", func(t *testing.T) {
+		// Act
+		calendar := decode(t,
+			"BEGIN:VEVENT",
+			"UID:1",
+			"SUMMARY:Planning\\, Q1",
+			"DTSTART;TZID=UTC:20240110T090000",
+			"DURATION:PT1H30M",
+			"LOCATION:Room 1",
+			"DESCRIPTION:Agenda:\\n- budget",
+			"ORGANIZER;CN=Sam:mailto:sam@example.com",
+			"ATTENDEE;CN=\"Doe, Jane\";ROLE=REQ-PARTICIPANT:mailto:jane@example.com",
+			"ATTENDEE:mailto:bob@exam",
+			" ple.com",
+			"BEGIN:VALARM",
+			"DESCRIPTION:Reminder",
+			"END:VALARM",
+			"END:VEVENT",
+		)
+		// Assert
+		assert.Len(t, calendar.Events, 1)
+		event := calendar.Events[0]
+		assert.Equal(t, "Planning, Q1", event.Summary)
+		assert.Equal(t, "Agenda:\n- budget", event.Description)
+		assert.Equal(t, "Room 1", event.Location)
+		assert.Equal(t, "Sam", event.Organizer)
+		assert.Equal(t, []string{"Doe, Jane", "bob@example.com"}, event.Attendees)
+		assert.Equal(t, time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC).Unix(), event.Start.Unix())
+		assert.Equal(t, 90*time.Minute, event.End.Sub(event.Start))
+	})
+
+	t.Run("All-day events", func(t *testing.T) {
+		calendar := decode(t, "BEGIN:VEVENT", "DTSTART;VALUE=DATE:20240110", "END:VEVENT")
+		assert.True(t, calendar.Events[0].AllDay)
+		assert.Equal(t, time.Date(2024, 1, 11, 0, 0, 0, 0, time.Local), calendar.Events[0].End)
+	})
+
+	t.Run("Unbalanced components", func(t *testing.T) {
+		_, err := ics.Decode(strings.NewReader("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\n"))
+		assert.EqualError(t, err, ics.ParseError)
+	})
+}
+
+func TestEventsOn(t *testing.T) {
+	t.Run("Expands recurring events with exceptions", func(t *testing.T) {
+		// Arrange
+		calendar := decode(t,
+			"BEGIN:VEVENT",
+			"UID:standup",
+			"SUMMARY:Standup",
+			"DTSTART:20240101T090000Z",
+			"DTEND:20240101T091500Z",
+			"RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR",
+			"EXDATE:20240110T090000Z",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:standup",
+			"RECURRENCE-ID:20240112T090000Z",
+			"SUMMARY:Standup (moved)",
+			"DTSTART:20240112T100000Z",
+			"DTEND:20240112T101500Z",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:lunch",
+			"SUMMARY:Lunch",
+			"STATUS:CANCELLED",
+			"DTSTART:20240108T120000Z",
+			"END:VEVENT",
+		)
+		day := func(d int) time.Time { return time.Date(2024, 1, d, 12, 0, 0, 0, time.UTC).In(time.Local) }
+		// Act & Assert
+		monday := ics.EventsOn(calendar, day(8))
+		assert.Len(t, monday, 1)
+		assert.Equal(t, time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC).Unix(), monday[0].Start.Unix())
+		assert.Equal(t, 15*time.Minute, monday[0].End.Sub(monday[0].Start))
+		assert.Empty(t, ics.EventsOn(calendar, day(9)))
+		assert.Empty(t, ics.EventsOn(calendar, day(10)))
+		friday := ics.EventsOn(calendar, day(12))
+		assert.Len(t, friday, 1)
+		assert.Equal(t, "Standup (moved)", friday[0].Summary)
+	})
+}
+
+func TestRRuleOccurrences(t *testing.T) {
+	start := time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC)
+	limit := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		rule string
+		want []string
+	}{
+		{"FREQ=DAILY;INTERVAL=2;COUNT=3", []string{"2024-01-31", "2024-02-02", "2024-02-04"}},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=WE;UNTIL=20240301", []string{"2024-01-31", "2024-02-14", "2024-02-28"}},
+		{"FREQ=MONTHLY;COUNT=4", []string{"2024-01-31", "2024-03-31", "2024-05-31"}},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3", []string{"2024-01-31", "2024-02-29", "2024-03-31"}},
+		{"FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", []string{"2024-02-23", "2024-03-29", "2024-04-26"}},
+		{"FREQ=YEARLY", []string{"2024-01-31"}},
+	}
+	for _, test := range tests {
+		t.Run(test.rule, func(t *testing.T) {
+			rule, err := ics.ParseRRule(test.rule)
+			assert.NoError(t, err)
+			var got []string
+			for _, occurrence := range rule.Occurrences(start, limit) {
+				got = append(got, occurrence.Format("2006-01-02"))
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("Rejects unsupported rules", func(t *testing.T) {
+		for _, rule := range []string{"FREQ=HOURLY", "FREQ=WEEKLY;BYDAY=XX", "FREQ=DAILY;INTERVAL=0"} {
+			_, err := ics.ParseRRule(rule)
+			assert.EqualError(t, err, ics.RRuleError, rule)
+		}
+	})
+}
@@ -0,0 +1,232 @@
+package ics
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRRulePeriods bounds the expansion of rules that never match.
+const maxRRulePeriods = 100000
+
+// RRule is the subset of an RFC 5545 recurrence rule that calendar apps use
+// for meetings: FREQ, INTERVAL, COUNT, UNTIL, BYDAY and BYMONTHDAY.
+type RRule struct {
+	Freq       string
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []RRuleDay
+	ByMonthDay []int
+}
+
+// RRuleDay is a BYDAY entry such as MO, or 2TU and -1FR in monthly rules.
+type RRuleDay struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// ParseRRule parses the value of an RRULE property.
+func ParseRRule(value string) (RRule, error) {
+	rule := RRule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(val)
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+			if rule.Interval < 1 {
+				err = errors.New(RRuleError)
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(val)
+		case "UNTIL":
+			rule.Until, _, err = parseDateTime(property{value: val})
+			if err == nil && len(val) == len("20060102") {
+				// An UNTIL date includes the whole day.
+				rule.Until = rule.Until.AddDate(0, 0, 1).Add(-time.Second)
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				day = strings.ToUpper(strings.TrimSpace(day))
+				if len(day) < 2 {
+					return RRule{}, errors.New(RRuleError)
+				}
+				weekday, ok := rruleWeekdays[day[len(day)-2:]]
+				if !ok {
+					return RRule{}, errors.New(RRuleError)
+				}
+				ordinal := 0
+				if prefix := day[:len(day)-2]; prefix != "" {
+					ordinal, err = strconv.Atoi(strings.TrimPrefix(prefix, "+"))
+				}
+				rule.ByDay = append(rule.ByDay, RRuleDay{Ordinal: ordinal, Weekday: weekday})
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(val, ",") {
+				n, convErr := strconv.Atoi(strings.TrimSpace(day))
+				if convErr != nil {
+					err = convErr
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		}
+		if err != nil {
+			return RRule{}, errors.New(RRuleError)
+		}
+	}
+
+	switch rule.Freq {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+		return rule, nil
+	}
+	return RRule{}, errors.New(RRuleError)
+}
+
+// Occurrences returns the start times of the occurrences of a rule beginning
+// at start, up to but not including limit. The time of day of start is kept.
+func (r RRule) Occurrences(start time.Time, limit time.Time) []time.Time {
+	var occurrences []time.Time
+	count := 0
+	for period := 0; period < maxRRulePeriods; period++ {
+		candidates := r.periodDays(start, period*r.Interval)
+		if len(candidates) == 0 && r.periodStart(start, period*r.Interval).After(limit) {
+			break
+		}
+		for _, day := range candidates {
+			occurrence := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+			if occurrence.Before(start) {
+				continue
+			}
+			if !r.Until.IsZero() && occurrence.After(r.Until) {
+				return occurrences
+			}
+			if r.Count > 0 && count >= r.Count {
+				return occurrences
+			}
+			if !occurrence.Before(limit) {
+				return occurrences
+			}
+			count++
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+	return occurrences
+}
+
+// periodStart returns the first day of the period offset periods after the
+// one containing start. Weeks start on Monday.
+func (r RRule) periodStart(start time.Time, offset int) time.Time {
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	switch r.Freq {
+	case "WEEKLY":
+		monday := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return monday.AddDate(0, 0, 7*offset)
+	case "MONTHLY":
+		return time.Date(day.Year(), day.Month()+time.Month(offset), 1, 0, 0, 0, 0, day.Location())
+	case "YEARLY":
+		return time.Date(day.Year()+offset, time.January, 1, 0, 0, 0, 0, day.Location())
+	}
+	return day.AddDate(0, 0, offset)
+}
+
+// periodDays returns the days of a period matched by the rule, in order.
+func (r RRule) periodDays(start time.Time, offset int) []time.Time {
+	first := r.periodStart(start, offset)
+	var days []time.Time
+	switch r.Freq {
+	case "DAILY":
+		if r.matchesWeekday(first) {
+			days = append(days, first)
+		}
+	case "WEEKLY":
+		for i := 0; i < 7; i++ {
+			day := first.AddDate(0, 0, i)
+			if len(r.ByDay) == 0 && day.Weekday() == start.Weekday() || len(r.ByDay) > 0 && r.matchesWeekday(day) {
+				days = append(days, day)
+			}
+		}
+	case "MONTHLY":
+		days = r.monthDays(first, start.Day())
+	case "YEARLY":
+		month := time.Date(first.Year(), start.Month(), 1, 0, 0, 0, 0, first.Location())
+		days = r.monthDays(month, start.Day())
+	}
+	return days
+}
+
+// monthDays returns the days of the month starting at first matched by
+// BYMONTHDAY and BYDAY, or the day of the month of the rule start.
+func (r RRule) monthDays(first time.Time, startDay int) []time.Time {
+	last := first.AddDate(0, 1, -1).Day()
+	matched := map[int]bool{}
+	for _, monthDay := range r.ByMonthDay {
+		if monthDay < 0 {
+			monthDay = last + monthDay + 1
+		}
+		if monthDay >= 1 && monthDay <= last {
+			matched[monthDay] = true
+		}
+	}
+	for _, byDay := range r.ByDay {
+		var weekdays []int
+		for day := 1; day <= last; day++ {
+			if first.AddDate(0, 0, day-1).Weekday() == byDay.Weekday {
+				weekdays = append(weekdays, day)
+			}
+		}
+		switch {
+		case byDay.Ordinal == 0:
+			for _, day := range weekdays {
+				matched[day] = true
+			}
+		case byDay.Ordinal > 0 && byDay.Ordinal <= len(weekdays):
+			matched[weekdays[byDay.Ordinal-1]] = true
+		case byDay.Ordinal < 0 && -byDay.Ordinal <= len(weekdays):
+			matched[weekdays[len(weekdays)+byDay.Ordinal]] = true
+		}
+	}
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 && startDay <= last {
+		matched[startDay] = true
+	}
+
+	var days []int
+	for day := range matched {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	result := make([]time.Time, len(days))
+	for i, day := range days {
+		result[i] = first.AddDate(0, 0, day-1)
+	}
+	return result
+}
+
+func (r RRule) matchesWeekday(day time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, byDay := range r.ByDay {
+		if byDay.Weekday == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
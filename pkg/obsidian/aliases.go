@@ -0,0 +1,102 @@
+package obsidian
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SearchEntry is a line in the note picker. Label is what is matched against
+// and Path is the vault relative path of the note it stands for.
+type SearchEntry struct {
+	Label string
+	Path  string
+}
+
+// NoteAliases returns the aliases in the frontmatter of a note, from either
+// the aliases or the alias property.
+func NoteAliases(content string) []string {
+	properties, _, err := ParseFrontmatter(content)
+	if err != nil {
+		return nil
+	}
+	var aliases []string
+	seen := map[string]bool{}
+	for _, key := range []string{"aliases", "alias"} {
+		for _, alias := range FrontmatterList(properties, key) {
+			alias = strings.TrimSpace(alias)
+			if alias == "" || seen[strings.ToLower(alias)] {
+				continue
+			}
+			seen[strings.ToLower(alias)] = true
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
+// NoteTitle returns the text of the first level 1 heading of a note, or ""
+// if it has none.
+func NoteTitle(content string) string {
+	for _, heading := range ParseHeadings(content) {
+		if heading.Level == 1 {
+			return heading.Text
+		}
+	}
+	return ""
+}
+
+// NoteSearchEntries returns an entry for each note path, followed by entries
+// for the aliases and H1 title of the note that differ from its file name.
+// Notes that cannot be read only get the entry for their path.
+func NoteSearchEntries(vaultPath string, notes []string) []SearchEntry {
+	entries := make([]SearchEntry, 0, len(notes))
+	for _, note := range notes {
+		entries = append(entries, SearchEntry{Label: note, Path: note})
+	}
+	for _, note := range notes {
+		data, err := os.ReadFile(filepath.Join(vaultPath, note))
+		if err != nil {
+			continue
+		}
+		content := string(data)
+		seen := map[string]bool{strings.ToLower(RemoveMdSuffix(filepath.Base(note))): true}
+		names := NoteAliases(content)
+		if title := NoteTitle(content); title != "" {
+			names = append(names, title)
+		}
+		for _, name := range names {
+			if seen[strings.ToLower(name)] {
+				continue
+			}
+			seen[strings.ToLower(name)] = true
+			entries = append(entries, SearchEntry{Label: name + " (" + filepath.ToSlash(note) + ")", Path: note})
+		}
+	}
+	return entries
+}
+
+// ResolveNoteName lets a note be named by one of its aliases. It returns the
+// vault relative path of the note with that alias, without the .md
+// extension. A name that is already the path or file name of a note, or that
+// no note has as an alias, is returned unchanged.
+func ResolveNoteName(vaultPath string, name string) string {
+	if strings.TrimSpace(name) == "" {
+		return name
+	}
+	if _, err := ResolveNotePath(vaultPath, name); err == nil {
+		return name
+	}
+
+	resolved := name
+	_ = WalkNotes(vaultPath, func(relPath string, content []byte) error {
+		for _, alias := range NoteAliases(string(content)) {
+			if strings.EqualFold(alias, strings.TrimSpace(name)) {
+				resolved = RemoveMdSuffix(relPath)
+				return errNoteFound
+			}
+		}
+		return nil
+	})
+	return resolved
+}
@@ -0,0 +1,71 @@
+package obsidian_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoteAliases(t *testing.T) {
+	t.Run("Reads aliases and alias", func(t *testing.T) {
+		// Act
+		aliases := obsidian.NoteAliases("---\naliases:\n  - Jane Doe\n  - JD\nalias: jane doe\n---\n")
+		// Assert
+		assert.Equal(t, []string{"Jane Doe", "JD"}, aliases)
+	})
+
+	t.Run("Note without frontmatter", func(t *testing.T) {
+		assert.Empty(t, obsidian.NoteAliases("# Jane\n"))
+	})
+}
+
+func TestNoteTitle(t *testing.T) {
+	assert.Equal(t, "Jane Doe", obsidian.NoteTitle("---\ntitle: x\n---\n## Intro\n# Jane Doe\n# Other\n"))
+	assert.Equal(t, "", obsidian.NoteTitle("```\n# Not a heading\n```\n"))
+}
+
+func TestNoteSearchEntries(t *testing.T) {
+	// Arrange
+	vaultPath := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(vaultPath, "people"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(vaultPath, "people", "P-0042.md"), []byte("---\naliases: [Jane Doe]\n---\n# Jane Doe\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(vaultPath, "Plain.md"), []byte("# Plain\n"), 0644))
+	notes := []string{"Plain.md", filepath.Join("people", "P-0042.md")}
+	// Act
+	entries := obsidian.NoteSearchEntries(vaultPath, notes)
+	// Assert
+	assert.Equal(t, []obsidian.SearchEntry{
+		{Label: "Plain.md", Path: "Plain.md"},
+		{Label: notes[1], Path: notes[1]},
+		{Label: "Jane Doe (people/P-0042.md)", Path: notes[1]},
+	}, entries)
+}
+
+func TestResolveNoteName(t *testing.T) {
+	// Arrange
+	vaultPath := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(vaultPath, "people"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(vaultPath, "people", "P-0042.md"), []byte("---\naliases: [Jane Doe]\n---\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(vaultPath, "Jane.md"), []byte("---\naliases: [P-0042]\n---\n"), 0644))
+
+	tests := []struct {
+		name     string
+		note     string
+		expected string
+	}{
+		{"Alias resolves to the note path", "jane doe", filepath.Join("people", "P-0042")},
+		{"File names win over aliases", "P-0042", "P-0042"},
+		{"Unknown names are unchanged", "Nobody", "Nobody"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			resolved := obsidian.ResolveNoteName(vaultPath, test.note)
+			// Assert
+			assert.Equal(t, test.expected, resolved)
+		})
+	}
+}
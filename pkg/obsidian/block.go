@@ -0,0 +1,156 @@
+package obsidian
+
+import (
+	"errors"
+	"math/rand"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	blockIDPattern  = regexp.MustCompile(`(?:^|[ \t])\^([A-Za-z0-9-]+)[ \t]*$`)
+	listItemPattern = regexp.MustCompile(`^[ \t>]*(?:[-*+]|\d+[.)])[ \t]`)
+)
+
+const blockIDAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// ExtractBlock returns the block of a note marked with ^id, without the
+// marker. A block is the list item or paragraph the marker ends, or the
+// block above when the marker is on a line of its own, as Obsidian does for
+// tables and quotes.
+func ExtractBlock(content string, id string) (string, error) {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	fence := ""
+	for i := frontmatterEnd(lines); i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r")
+		if marker := codeFenceMarker(line); marker != "" {
+			if fence == "" {
+				fence = marker
+			} else if strings.HasPrefix(marker, fence) {
+				fence = ""
+			}
+			continue
+		}
+		match := blockIDPattern.FindStringSubmatchIndex(line)
+		if fence != "" || match == nil || !strings.EqualFold(line[match[2]:match[3]], id) {
+			continue
+		}
+
+		text := strings.TrimRight(line[:match[0]], " \t")
+		end := i
+		if text == "" {
+			// Complex blocks such as tables are marked on the line after
+			// them, usually separated by a blank line.
+			end = i - 1
+			for end >= 0 && strings.TrimSpace(lines[end]) == "" {
+				end--
+			}
+			if end < 0 {
+				break
+			}
+			text = strings.TrimRight(lines[end], "\r")
+		} else if listItemPattern.MatchString(line) || headingPattern.MatchString(line) {
+			return text + "\n", nil
+		}
+
+		start := end
+		for start > 0 && strings.TrimSpace(lines[start-1]) != "" && !headingPattern.MatchString(strings.TrimRight(lines[start-1], "\r")) {
+			start--
+		}
+		var block []string
+		for _, blockLine := range lines[start:end] {
+			block = append(block, strings.TrimRight(blockLine, "\r"))
+		}
+		block = append(block, text)
+		return strings.Join(block, "\n") + "\n", nil
+	}
+	return "", errors.New(BlockNotFoundError)
+}
+
+// ExtractSubpath returns the part of a note a link subpath points to: a
+// block for ^id, or the section under a heading otherwise.
+func ExtractSubpath(content string, subpath string) (string, error) {
+	if strings.HasPrefix(subpath, "^") {
+		return ExtractBlock(content, subpath[1:])
+	}
+	return ExtractSection(content, subpath)
+}
+
+// AddBlockID marks the paragraph or list item at lineNumber with a block ID
+// so it can be linked to, and returns the updated content and the ID. The ID
+// goes at the end of the last line of a paragraph. A block that already has
+// an ID keeps it.
+func AddBlockID(content string, lineNumber int) (string, string, error) {
+	lines := strings.Split(content, "\n")
+	index := lineNumber - 1
+	if index < frontmatterEnd(lines) || index >= len(lines) || inCodeBlock(lines, index) {
+		return "", "", errors.New(BlockLineError)
+	}
+	line := strings.TrimRight(lines[index], "\r")
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || headingPattern.MatchString(line) || strings.HasPrefix(trimmed, "|") || strings.HasPrefix(trimmed, "^") {
+		return "", "", errors.New(BlockLineError)
+	}
+
+	if !listItemPattern.MatchString(line) {
+		for index+1 < len(lines) && isParagraphContinuation(strings.TrimRight(lines[index+1], "\r")) {
+			index++
+		}
+		line = strings.TrimRight(lines[index], "\r")
+	}
+	if match := blockIDPattern.FindStringSubmatch(line); match != nil {
+		return content, match[1], nil
+	}
+
+	id := newBlockID(content)
+	lines[index] = strings.TrimRight(line, " \t") + " ^" + id + lines[index][len(line):]
+	return strings.Join(lines, "\n"), id, nil
+}
+
+// BlockLink returns the wikilink to a block of a note.
+func BlockLink(noteName string, id string) string {
+	return WikiLink{Target: noteName, Subpath: "^" + id}.String()
+}
+
+func isParagraphContinuation(line string) bool {
+	return strings.TrimSpace(line) != "" &&
+		!headingPattern.MatchString(line) &&
+		!listItemPattern.MatchString(line) &&
+		codeFenceMarker(line) == "" &&
+		!strings.HasPrefix(strings.TrimSpace(line), "^")
+}
+
+func inCodeBlock(lines []string, index int) bool {
+	fence := ""
+	for i := frontmatterEnd(lines); i <= index; i++ {
+		marker := codeFenceMarker(strings.TrimRight(lines[i], "\r"))
+		if marker == "" {
+			continue
+		}
+		if i == index {
+			return true
+		}
+		if fence == "" {
+			fence = marker
+		} else if strings.HasPrefix(marker, fence) {
+			fence = ""
+		}
+	}
+	return fence != ""
+}
+
+// newBlockID generates a random ID like the ones Obsidian creates, making
+// sure it is not used in the note yet.
+func newBlockID(content string) string {
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for {
+		id := make([]byte, 6)
+		for i := range id {
+			id[i] = blockIDAlphabet[random.Intn(len(blockIDAlphabet))]
+		}
+		if !strings.Contains(content, "^"+string(id)) {
+			return string(id)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package obsidian_test
+
+import (
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractBlock(t *testing.T) {
+	content := "# Title\nFirst line\nsecond line ^para\n\n- one\n- two ^item\n\n| a | b |\n| - | - |\n\n^table\n```\ncode ^code\n```\n"
+
+	tests := []struct {
+		name     string
+		id       string
+		expected string
+	}{
+		{"Paragraph", "para", "First line\nsecond line\n"},
+		{"List item", "ITEM", "- two\n"},
+		{"Marker on its own line", "table", "| a | b |\n| - | - |\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			block, err := obsidian.ExtractBlock(content, test.id)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, block)
+		})
+	}
+
+	t.Run("Markers in code are ignored", func(t *testing.T) {
+		_, err := obsidian.ExtractBlock(content, "code")
+		assert.EqualError(t, err, obsidian.BlockNotFoundError)
+	})
+}
+
+func TestAddBlockID(t *testing.T) {
+	content := "# Title\nFirst line\nsecond line\n\n- item\n- marked ^abc\n```\ncode\n```\n"
+
+	t.Run("Marks the end of a paragraph", func(t *testing.T) {
+		// Act
+		updated, id, err := obsidian.AddBlockID(content, 2)
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, id, 6)
+		assert.Equal(t, "# Title\nFirst line\nsecond line ^"+id+"\n\n- item\n- marked ^abc\n```\ncode\n```\n", updated)
+	})
+
+	t.Run("Marks a list item", func(t *testing.T) {
+		updated, id, err := obsidian.AddBlockID(content, 5)
+		assert.NoError(t, err)
+		assert.Contains(t, updated, "\n- item ^"+id+"\n")
+	})
+
+	t.Run("Keeps an existing ID", func(t *testing.T) {
+		updated, id, err := obsidian.AddBlockID(content, 6)
+		assert.NoError(t, err)
+		assert.Equal(t, "abc", id)
+		assert.Equal(t, content, updated)
+	})
+
+	t.Run("Headings, blank lines and code cannot be marked", func(t *testing.T) {
+		for _, line := range []int{1, 4, 8, 20} {
+			_, _, err := obsidian.AddBlockID(content, line)
+			assert.EqualError(t, err, obsidian.BlockLineError)
+		}
+	})
+}
+
+func TestBlockLink(t *testing.T) {
+	assert.Equal(t, "[[Note#^abc]]", obsidian.BlockLink("Note", "abc"))
+}
@@ -0,0 +1,57 @@
+package obsidian
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// ReadCliConfig reads the preferences of the CLI. A missing file gives the
+// empty config.
+func ReadCliConfig() (CliConfig, error) {
+	_, cliConfigFile, err := CliConfigPath()
+	if err != nil {
+		return CliConfig{}, err
+	}
+
+	content, err := os.ReadFile(cliConfigFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return CliConfig{}, nil
+	}
+	if err != nil {
+		return CliConfig{}, errors.New(ObsidianCLIConfigReadError)
+	}
+
+	cliConfig := CliConfig{}
+	err = json.Unmarshal(content, &cliConfig)
+	if err != nil {
+		return CliConfig{}, errors.New(ObsidianCLIConfigParseError)
+	}
+	return cliConfig, nil
+}
+
+// WriteCliConfig saves the preferences of the CLI, creating its directory
+// if needed.
+func WriteCliConfig(cliConfig CliConfig) error {
+	jsonContent, err := JsonMarshal(cliConfig)
+	if err != nil {
+		return errors.New(ObsidianCLIConfigGenerateJSONError)
+	}
+
+	obsConfigDir, obsConfigFile, err := CliConfigPath()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(obsConfigDir, os.ModePerm)
+	if err != nil {
+		return errors.New(ObsidianCLIConfigDirWriteEror)
+	}
+
+	err = os.WriteFile(obsConfigFile, jsonContent, 0644)
+	if err != nil {
+		return errors.New(ObsidianCLIConfigWriteError)
+	}
+	return nil
+}
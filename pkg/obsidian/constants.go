@@ -3,6 +3,7 @@ package obsidian
 const (
 	ExecuteUriError                    = "Failed to execute Obsidian URI"
 	NoteDoesNotExistError              = "Cannot find note in vault"
+	NoteOutsideVaultError              = "Invalid note name, the note has to be inside the vault"
 	VaultAccessError                   = "Failed to access vault directory"
 	VaultReadError                     = "Failed to read notes in vault"
 	VaultWriteError                    = "Failed to write to update notes in vault"
@@ -14,4 +15,27 @@ const (
 	ObsidianConfigReadError            = "Failed to read Obsidian config file. Please ensure vault has been set up in Obsidian."
 	ObsidianConfigParseError           = "Failed to parse Obsidian config file. Please ensure vault has been set up in Obsidian."
 	ObsidianConfigVaultNotFoundError   = "Vault not found in Obsidian config file. Please ensure vault has been set up in Obsidian."
+	DailyNotesConfigParseError         = "Failed to parse daily notes settings. Please check .obsidian/daily-notes.json in your vault."
+	TemplateReadError                  = "Failed to read template file. Please ensure the template exists in your vault."
+	TemplatesConfigParseError          = "Failed to parse templates settings. Please check .obsidian/templates.json in your vault."
+	TemplatesFolderNotSetError         = "Templates folder is not set. Please configure the Templates core plugin in Obsidian."
+	TemplateNotFoundError              = "Cannot find template in templates folder"
+	InvalidDateError                   = "Invalid date, please use YYYY-MM-DD, today, yesterday or tomorrow"
+	InvalidTaskStatusError             = "Invalid task status, please use todo, done, cancelled or all"
+	TaskNotFoundError                  = "Cannot find task in vault, please list tasks to get its ID"
+	PreviousDailyNoteNotFoundError     = "Cannot find a daily note from the past year to roll tasks over from"
+	InvalidRecurrenceError             = "Cannot understand the task recurrence rule, e.g. use \"every week on Monday\" or \"every month when done\""
+	FrontmatterParseError              = "Failed to parse note frontmatter, please check it is valid YAML"
+	TaskChangedError                   = "Task line has changed since it was listed, please list tasks again"
+	SectionNotFoundError               = "Cannot find heading in note, please check the outline of the note"
+	InvalidHeadingError                = "Invalid heading, the new heading cannot be empty"
+	BlockNotFoundError                 = "Cannot find block in note, please check the block ID"
+	BlockLineError                     = "Line is not a paragraph or list item, block IDs can only be added to those"
+	InvalidTagError                    = "Invalid tag, tags cannot be empty or contain spaces"
+	InvalidCommandError                = "Invalid command, please give a command ID such as editor:toggle-bold"
+	InvalidWorkspaceError              = "Invalid workspace, please give the name of a saved workspace"
+	InvalidPropertyError               = "Invalid property, the property name cannot be empty"
+	PropertyValueUriError              = "Cannot write an empty or false property through Obsidian, please edit the note instead"
+	EditorParseError                   = "Cannot parse editor command, please check its quotes"
+	InvalidEditorCommandError          = "Invalid editor command, use {file} where the note path goes, e.g. \"{editor} +{line} {file}\""
 )
@@ -0,0 +1,109 @@
+package obsidian
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/config"
+)
+
+// DailyNotesConfig mirrors the settings of Obsidian's Daily notes core
+// plugin, stored in .obsidian/daily-notes.json.
+type DailyNotesConfig struct {
+	Folder   string `json:"folder"`
+	Format   string `json:"format"`
+	Template string `json:"template"`
+}
+
+// ReadDailyNotesConfig reads the daily notes settings of the vault. A vault
+// without the settings file uses the plugin defaults.
+func ReadDailyNotesConfig(vaultPath string) (DailyNotesConfig, error) {
+	dailyNotesConfig := DailyNotesConfig{}
+
+	content, err := os.ReadFile(filepath.Join(vaultPath, config.VaultConfigDirectory, config.DailyNotesConfigFile))
+	if err == nil {
+		err = json.Unmarshal(content, &dailyNotesConfig)
+		if err != nil {
+			return DailyNotesConfig{}, errors.New(DailyNotesConfigParseError)
+		}
+	} else if !os.IsNotExist(err) {
+		return DailyNotesConfig{}, errors.New(DailyNotesConfigParseError)
+	}
+
+	if strings.TrimSpace(dailyNotesConfig.Format) == "" {
+		dailyNotesConfig.Format = DefaultDateFormat
+	}
+	dailyNotesConfig.Folder = strings.Trim(dailyNotesConfig.Folder, "/")
+	return dailyNotesConfig, nil
+}
+
+// DailyNotePath returns the vault relative path of the daily note for date.
+func DailyNotePath(dailyNotesConfig DailyNotesConfig, date time.Time) string {
+	name := FormatMomentDate(date, dailyNotesConfig.Format)
+	return AddMdSuffix(filepath.Join(dailyNotesConfig.Folder, name))
+}
+
+// CreateDailyNote writes the daily note for date directly to disk, seeded
+// from the configured template, so it works without Obsidian running. An
+// existing note is left untouched. It returns the vault relative note path.
+func CreateDailyNote(vaultPath string, date time.Time) (string, error) {
+	dailyNotesConfig, err := ReadDailyNotesConfig(vaultPath)
+	if err != nil {
+		return "", err
+	}
+
+	notePath := DailyNotePath(dailyNotesConfig, date)
+	fullPath := filepath.Join(vaultPath, notePath)
+	if _, err := os.Stat(fullPath); err == nil {
+		return notePath, nil
+	}
+
+	content := ""
+	if dailyNotesConfig.Template != "" {
+		template, err := ReadTemplate(vaultPath, dailyNotesConfig.Template)
+		if err != nil {
+			return "", err
+		}
+		// Like Obsidian, {{date}} renders with the daily note format.
+		content = RenderTemplate(template, TemplateData{
+			Title:      RemoveMdSuffix(filepath.Base(notePath)),
+			Date:       date,
+			DateFormat: dailyNotesConfig.Format,
+		})
+	}
+
+	err = os.MkdirAll(filepath.Dir(fullPath), 0755)
+	if err != nil {
+		return "", errors.New(VaultWriteError)
+	}
+	err = os.WriteFile(fullPath, []byte(content), 0644)
+	if err != nil {
+		return "", errors.New(VaultWriteError)
+	}
+
+	fmt.Println("Created daily note: ", notePath)
+	return notePath, nil
+}
+
+// DailyNoteDate returns the date of the daily note at the vault relative
+// notePath, and false if the note is not a daily note.
+func DailyNoteDate(dailyNotesConfig DailyNotesConfig, notePath string) (time.Time, bool) {
+	name := RemoveMdSuffix(filepath.ToSlash(notePath))
+	if dailyNotesConfig.Folder != "" {
+		prefix := filepath.ToSlash(dailyNotesConfig.Folder) + "/"
+		if !strings.HasPrefix(name, prefix) {
+			return time.Time{}, false
+		}
+		name = strings.TrimPrefix(name, prefix)
+	}
+	date, err := ParseMomentDate(name, dailyNotesConfig.Format)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
@@ -0,0 +1,87 @@
+package obsidian_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func readVaultFile(t *testing.T, vaultPath string, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(vaultPath, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestReadDailyNotesConfig(t *testing.T) {
+	t.Run("Defaults when settings file does not exist", func(t *testing.T) {
+		// Act
+		dailyNotesConfig, err := obsidian.ReadDailyNotesConfig(t.TempDir())
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, obsidian.DailyNotesConfig{Format: "YYYY-MM-DD"}, dailyNotesConfig)
+	})
+
+	t.Run("Reads settings file", func(t *testing.T) {
+		// Arrange
+		vaultPath := mocks.CreateVaultFiles(t, map[string]string{".obsidian/daily-notes.json": `{"folder":"Journal/","format":"DD-MM-YYYY","template":"Templates/Daily"}`})
+		// Act
+		dailyNotesConfig, err := obsidian.ReadDailyNotesConfig(vaultPath)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, obsidian.DailyNotesConfig{Folder: "Journal", Format: "DD-MM-YYYY", Template: "Templates/Daily"}, dailyNotesConfig)
+	})
+
+	t.Run("Invalid settings file", func(t *testing.T) {
+		// Arrange
+		vaultPath := mocks.CreateVaultFiles(t, map[string]string{".obsidian/daily-notes.json": `{"folder":`})
+		// Act
+		_, err := obsidian.ReadDailyNotesConfig(vaultPath)
+		// Assert
+		assert.Equal(t, obsidian.DailyNotesConfigParseError, err.Error())
+	})
+}
+
+func TestCreateDailyNote(t *testing.T) {
+	date := time.Date(2024, time.February, 29, 7, 45, 0, 0, time.UTC)
+
+	t.Run("Creates note from template", func(t *testing.T) {
+		// Arrange
+		vaultPath := mocks.CreateVaultFiles(t, map[string]string{
+			".obsidian/daily-notes.json": `{"folder":"Journal","format":"YYYY/MM/dddd D","template":"Templates/Daily"}`,
+			"Templates/Daily.md":         "# {{title}}\nDate: {{date}} ({{date:YYYY-MM-DD}}) at {{time}}\n",
+		})
+		// Act
+		notePath, err := obsidian.CreateDailyNote(vaultPath, date)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join("Journal", "2024", "02", "Thursday 29.md"), notePath)
+		assert.Equal(t, "# Thursday 29\nDate: 2024/02/Thursday 29 (2024-02-29) at 07:45\n", readVaultFile(t, vaultPath, notePath))
+	})
+
+	t.Run("Does not overwrite existing note", func(t *testing.T) {
+		// Arrange
+		vaultPath := mocks.CreateVaultFiles(t, map[string]string{"2024-02-29.md": "existing"})
+		// Act
+		notePath, err := obsidian.CreateDailyNote(vaultPath, date)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "existing", readVaultFile(t, vaultPath, notePath))
+	})
+
+	t.Run("Missing template", func(t *testing.T) {
+		// Arrange
+		vaultPath := mocks.CreateVaultFiles(t, map[string]string{".obsidian/daily-notes.json": `{"template":"Templates/Missing"}`})
+		// Act
+		_, err := obsidian.CreateDailyNote(vaultPath, date)
+		// Assert
+		assert.Equal(t, obsidian.TemplateReadError, err.Error())
+	})
+}
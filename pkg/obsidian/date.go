@@ -0,0 +1,30 @@
+package obsidian
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const DateLayout = "2006-01-02"
+
+// ParseDateArgument parses a date given on the command line. It accepts
+// YYYY-MM-DD as well as the keywords today, yesterday and tomorrow, all
+// relative to now. The time of day of now is kept so that {{time}}
+// placeholders still reflect the current time.
+func ParseDateArgument(value string, now time.Time) (time.Time, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "today":
+		return now, nil
+	case "yesterday":
+		return now.AddDate(0, 0, -1), nil
+	case "tomorrow":
+		return now.AddDate(0, 0, 1), nil
+	}
+
+	date, err := time.ParseInLocation(DateLayout, strings.TrimSpace(value), now.Location())
+	if err != nil {
+		return time.Time{}, errors.New(InvalidDateError)
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), now.Hour(), now.Minute(), now.Second(), 0, now.Location()), nil
+}
@@ -0,0 +1,349 @@
+package obsidian
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// editorPositions gives the arguments opening a file at a line, and at a
+// column when it is known, for the editors that can, by executable name.
+var editorPositions = map[string]func(filePath string, line int, column int) []string{
+	"vi":            vimPosition,
+	"vim":           vimPosition,
+	"nvim":          vimPosition,
+	"gvim":          vimPosition,
+	"mvim":          vimPosition,
+	"nano":          plusPosition(","),
+	"emacs":         plusPosition(":"),
+	"emacsclient":   plusPosition(":"),
+	"micro":         plusPosition(":"),
+	"kak":           plusPosition(":"),
+	"code":          gotoPosition,
+	"code-insiders": gotoPosition,
+	"codium":        gotoPosition,
+	"code-oss":      gotoPosition,
+	"vscodium":      gotoPosition,
+	"subl":          suffixPosition,
+	"sublime_text":  suffixPosition,
+	"hx":            suffixPosition,
+	"helix":         suffixPosition,
+	"zed":           suffixPosition,
+}
+
+func vimPosition(filePath string, line int, column int) []string {
+	if column > 0 {
+		return []string{fmt.Sprintf("+call cursor(%d,%d)", line, byteColumn(filePath, line, column)), filePath}
+	}
+	return []string{"+" + strconv.Itoa(line), filePath}
+}
+
+// byteColumn turns a column counted in characters into one counted in bytes,
+// as Vim's cursor() takes it, using the line in the file. The column is kept
+// when the line cannot be read.
+func byteColumn(filePath string, line int, column int) int {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return column
+	}
+	lines := strings.Split(string(content), "\n")
+	if line > len(lines) {
+		return column
+	}
+	runes := []rune(lines[line-1])
+	if column-1 > len(runes) {
+		return column
+	}
+	return len(string(runes[:column-1])) + 1
+}
+
+func plusPosition(separator string) func(string, int, int) []string {
+	return func(filePath string, line int, column int) []string {
+		if column > 0 {
+			return []string{"+" + strconv.Itoa(line) + separator + strconv.Itoa(column), filePath}
+		}
+		return []string{"+" + strconv.Itoa(line), filePath}
+	}
+}
+
+func gotoPosition(filePath string, line int, column int) []string {
+	return []string{"--goto", filePathAt(filePath, line, column)}
+}
+
+func suffixPosition(filePath string, line int, column int) []string {
+	return []string{filePathAt(filePath, line, column)}
+}
+
+func filePathAt(filePath string, line int, column int) string {
+	if column > 0 {
+		return fmt.Sprintf("%s:%d:%d", filePath, line, column)
+	}
+	return fmt.Sprintf("%s:%d", filePath, line)
+}
+
+// defaultWaitFlags are the flags GUI editors need to block until the file
+// is closed, by executable name. The editor_wait_flags preference adds to
+// and overrides them, with an empty flag for none.
+var defaultWaitFlags = map[string]string{
+	"code":          "--wait",
+	"code-insiders": "--wait",
+	"codium":        "--wait",
+	"code-oss":      "--wait",
+	"vscodium":      "--wait",
+	"subl":          "--wait",
+	"sublime_text":  "--wait",
+	"atom":          "--wait",
+	"mate":          "--wait",
+	"zed":           "--wait",
+}
+
+// PreferredEditor returns the editor command to open notes with: the editor
+// preference, then $VISUAL when running in a terminal, then $EDITOR, and
+// vim when none is set.
+func PreferredEditor(cliConfig CliConfig, interactive bool) string {
+	if cliConfig.Editor != "" {
+		return cliConfig.Editor
+	}
+	if visual := os.Getenv("VISUAL"); visual != "" && interactive {
+		return visual
+	}
+	if editor := os.Getenv("EDITOR"); editor != "" {
+		return editor
+	}
+	return "vim" // Default fallback
+}
+
+// EditorArgs returns the command line opening a file in editor, at a line
+// and column when they are above zero. The editor can carry arguments of its
+// own, such as "emacsclient -t", quoted as in a shell. The editor_command
+// preference, such as "{editor} +{line} {file}", takes the place of the
+// built-in knowledge of editors, with {column} also available. Lines and
+// columns default to 1 in templates.
+func EditorArgs(editor string, cliConfig CliConfig, filePath string, line int, column int) ([]string, error) {
+	editorArgs, err := SplitCommand(editor)
+	if err != nil {
+		return nil, err
+	}
+	if len(editorArgs) == 0 {
+		return nil, errors.New(EditorParseError)
+	}
+
+	if cliConfig.EditorCommand != "" {
+		return templateArgs(editorArgs, cliConfig.EditorCommand, filePath, line, column)
+	}
+
+	name := editorName(editorArgs[0])
+	args := editorArgs
+	if flag := editorWaitFlag(name, cliConfig.EditorWaitFlags); flag != "" && !containsString(editorArgs[1:], flag) {
+		args = append(args, flag)
+	}
+
+	position, ok := editorPositions[name]
+	if line <= 0 || !ok {
+		return append(args, filePath), nil
+	}
+	return append(args, position(filePath, line, column)...), nil
+}
+
+func templateArgs(editorArgs []string, template string, filePath string, line int, column int) ([]string, error) {
+	fields, err := SplitCommand(template)
+	if err != nil {
+		return nil, err
+	}
+	if line <= 0 {
+		line = 1
+	}
+	if column <= 0 {
+		column = 1
+	}
+	replacer := strings.NewReplacer(
+		"{editor}", strings.Join(editorArgs, " "),
+		"{file}", filePath,
+		"{line}", strconv.Itoa(line),
+		"{column}", strconv.Itoa(column),
+	)
+
+	var args []string
+	for _, field := range fields {
+		if field == "{editor}" {
+			// The editor keeps its own arguments apart.
+			args = append(args, editorArgs...)
+			continue
+		}
+		args = append(args, replacer.Replace(field))
+	}
+	return args, nil
+}
+
+// editorName returns the executable name of an editor, such as "code" for
+// /usr/local/bin/code, Code.exe or the code.cmd launcher on Windows.
+func editorName(executable string) string {
+	name := strings.ToLower(filepath.Base(executable))
+	switch filepath.Ext(name) {
+	case ".exe", ".cmd", ".bat":
+		return strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return name
+}
+
+func editorWaitFlag(name string, overrides map[string]string) string {
+	if flag, ok := overrides[name]; ok {
+		return flag
+	}
+	return defaultWaitFlags[name]
+}
+
+func containsString(items []string, item string) bool {
+	for _, candidate := range items {
+		if candidate == item {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitCommand splits a command line into its arguments the way a shell
+// would, without expanding anything. Single quotes keep everything as is,
+// double quotes let a backslash escape a quote or a backslash, and outside
+// quotes a backslash escapes a space, a quote or a backslash, so that
+// Windows paths work unquoted.
+func SplitCommand(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	quote := rune(0)
+	runes := []rune(command)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else if r == '\\' && escapes(runes, i, `"\`) {
+				i++
+				current.WriteRune(runes[i])
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\\' && escapes(runes, i, "\"'\\ \t"):
+			i++
+			current.WriteRune(runes[i])
+			inArg = true
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New(EditorParseError)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// escapes reports whether the backslash at i escapes the next rune.
+func escapes(runes []rune, i int, escapable string) bool {
+	return i+1 < len(runes) && strings.ContainsRune(escapable, runes[i+1])
+}
+
+// OpenInEditor opens the specified file path in the user's preferred editor
+// It supports common GUI editors with appropriate wait flags
+func OpenInEditor(filePath string) error {
+	return OpenInEditorAt(filePath, 0, 0)
+}
+
+// OpenInEditorAt opens the file in the user's preferred editor with the
+// cursor at a line and column, where the editor supports it. Zero leaves
+// the line or column to the editor.
+func OpenInEditorAt(filePath string, line int, column int) error {
+	// The preferences only tune the editor, without them it still opens.
+	cliConfig, err := ReadCliConfig()
+	if err != nil {
+		cliConfig = CliConfig{}
+	}
+
+	editor := PreferredEditor(cliConfig, isTerminal(os.Stdin) && isTerminal(os.Stdout))
+	args, err := EditorArgs(editor, cliConfig, filePath, line, column)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to open file in editor '%s': %w", editor, err)
+	}
+
+	return nil
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// SetEditor saves the editor to open notes with in place of $VISUAL and
+// $EDITOR. An empty editor goes back to those.
+func SetEditor(editor string) error {
+	if _, err := SplitCommand(editor); err != nil {
+		return err
+	}
+	cliConfig, err := ReadCliConfig()
+	if err != nil {
+		return err
+	}
+	cliConfig.Editor = editor
+	return WriteCliConfig(cliConfig)
+}
+
+// SetEditorWaitFlag saves the flag an editor needs to block until the file
+// is closed, by executable name. An empty flag stops one being passed.
+func SetEditorWaitFlag(name string, flag string) error {
+	cliConfig, err := ReadCliConfig()
+	if err != nil {
+		return err
+	}
+	if cliConfig.EditorWaitFlags == nil {
+		cliConfig.EditorWaitFlags = map[string]string{}
+	}
+	cliConfig.EditorWaitFlags[editorName(name)] = flag
+	return WriteCliConfig(cliConfig)
+}
+
+// SetEditorCommand saves the command template used to open notes in the
+// editor. An empty template goes back to the built-in editor support.
+func SetEditorCommand(template string) error {
+	if template != "" && !strings.Contains(template, "{file}") {
+		return errors.New(InvalidEditorCommandError)
+	}
+	cliConfig, err := ReadCliConfig()
+	if err != nil {
+		return err
+	}
+	cliConfig.EditorCommand = template
+	return WriteCliConfig(cliConfig)
+}
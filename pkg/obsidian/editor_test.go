@@ -0,0 +1,184 @@
+package obsidian_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditorArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		editor    string
+		cliConfig obsidian.CliConfig
+		line      int
+		column    int
+		expected  []string
+	}{
+		{"No line", "vim", obsidian.CliConfig{}, 0, 0, []string{"vim", "note.md"}},
+		{"Vim", "/usr/bin/vim", obsidian.CliConfig{}, 12, 0, []string{"/usr/bin/vim", "+12", "note.md"}},
+		{"Vim with column", "nvim", obsidian.CliConfig{}, 12, 4, []string{"nvim", "+call cursor(12,4)", "note.md"}},
+		{"Nano", "nano", obsidian.CliConfig{}, 12, 4, []string{"nano", "+12,4", "note.md"}},
+		{"Emacs", "emacs", obsidian.CliConfig{}, 12, 0, []string{"emacs", "+12", "note.md"}},
+		{"VS Code", "code", obsidian.CliConfig{}, 12, 4, []string{"code", "--wait", "--goto", "note.md:12:4"}},
+		{"Code - OSS", "code-oss", obsidian.CliConfig{}, 12, 4, []string{"code-oss", "--wait", "--goto", "note.md:12:4"}},
+		{"VSCodium", "vscodium", obsidian.CliConfig{}, 12, 0, []string{"vscodium", "--wait", "--goto", "note.md:12"}},
+		{"Sublime Text", "subl", obsidian.CliConfig{}, 12, 0, []string{"subl", "--wait", "note.md:12"}},
+		{"VS Code launcher on Windows", "code.cmd", obsidian.CliConfig{}, 12, 4, []string{"code.cmd", "--wait", "--goto", "note.md:12:4"}},
+		{"Sublime Text launcher on Windows", "Subl.BAT", obsidian.CliConfig{}, 12, 0, []string{"Subl.BAT", "--wait", "note.md:12"}},
+		{"Sublime Text executable", "/opt/sublime_text/sublime_text", obsidian.CliConfig{}, 12, 0, []string{"/opt/sublime_text/sublime_text", "--wait", "note.md:12"}},
+		{"Helix", "hx", obsidian.CliConfig{}, 12, 0, []string{"hx", "note.md:12"}},
+		{"Unknown editor", "ed", obsidian.CliConfig{}, 12, 0, []string{"ed", "note.md"}},
+		{"Editor with arguments", "emacsclient -t", obsidian.CliConfig{}, 12, 0, []string{"emacsclient", "-t", "+12", "note.md"}},
+		{"Quoted editor path", `"/Applications/Visual Studio Code.app/code" -n`, obsidian.CliConfig{}, 0, 0, []string{"/Applications/Visual Studio Code.app/code", "-n", "--wait", "note.md"}},
+		{"Wait flag already given", "code --wait", obsidian.CliConfig{}, 0, 0, []string{"code", "--wait", "note.md"}},
+		{"No wait flag for a similar name", "codium-wrapper", obsidian.CliConfig{}, 0, 0, []string{"codium-wrapper", "note.md"}},
+		{"Configured wait flag", "gvim", obsidian.CliConfig{EditorWaitFlags: map[string]string{"gvim": "-f"}}, 12, 0, []string{"gvim", "-f", "+12", "note.md"}},
+		{"Wait flag turned off", "code", obsidian.CliConfig{EditorWaitFlags: map[string]string{"code": ""}}, 0, 0, []string{"code", "note.md"}},
+		{"Template", "kate", obsidian.CliConfig{EditorCommand: "{editor} --line {line} --column {column} {file}"}, 12, 0, []string{"kate", "--line", "12", "--column", "1", "note.md"}},
+		{"Template without line", "vim", obsidian.CliConfig{EditorCommand: "{editor} +{line} {file}"}, 0, 0, []string{"vim", "+1", "note.md"}},
+		{"Template with editor arguments", "emacsclient -t", obsidian.CliConfig{EditorCommand: "{editor} '+{line}' {file}"}, 3, 0, []string{"emacsclient", "-t", "+3", "note.md"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			args, err := obsidian.EditorArgs(test.editor, test.cliConfig, "note.md", test.line, test.column)
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, args)
+		})
+	}
+
+	t.Run("Vim columns on a line that is not ASCII", func(t *testing.T) {
+		// Arrange
+		filePath := filepath.Join(t.TempDir(), "note.md")
+		assert.NoError(t, os.WriteFile(filePath, []byte("# Café\ncafé ✅ target\n"), 0644))
+		// Act
+		vimArgs, err := obsidian.EditorArgs("vim", obsidian.CliConfig{}, filePath, 2, 8)
+		assert.NoError(t, err)
+		codeArgs, err := obsidian.EditorArgs("code", obsidian.CliConfig{}, filePath, 2, 8)
+		assert.NoError(t, err)
+		// Assert
+		assert.Equal(t, []string{"vim", "+call cursor(2,11)", filePath}, vimArgs)
+		assert.Equal(t, []string{"code", "--wait", "--goto", filePath + ":2:8"}, codeArgs)
+	})
+
+	t.Run("Unclosed quote", func(t *testing.T) {
+		_, err := obsidian.EditorArgs(`"code -n`, obsidian.CliConfig{}, "note.md", 0, 0)
+		assert.EqualError(t, err, obsidian.EditorParseError)
+	})
+}
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		expected []string
+	}{
+		{"Spaces", "  code   -n ", []string{"code", "-n"}},
+		{"Single quotes", `vim '+set ft=markdown'`, []string{"vim", "+set ft=markdown"}},
+		{"Double quotes", `"my editor" "say \"hi\""`, []string{"my editor", `say "hi"`}},
+		{"Escaped space", `my\ editor -w`, []string{"my editor", "-w"}},
+		{"Windows path", `C:\tools\vim.exe`, []string{`C:\tools\vim.exe`}},
+		{"Empty quotes", `editor ""`, []string{"editor", ""}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			args, err := obsidian.SplitCommand(test.command)
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, args)
+		})
+	}
+}
+
+func TestPreferredEditor(t *testing.T) {
+	t.Run("Prefers VISUAL in a terminal", func(t *testing.T) {
+		t.Setenv("VISUAL", "nvim")
+		t.Setenv("EDITOR", "ed")
+		assert.Equal(t, "nvim", obsidian.PreferredEditor(obsidian.CliConfig{}, true))
+		assert.Equal(t, "ed", obsidian.PreferredEditor(obsidian.CliConfig{}, false))
+	})
+
+	t.Run("Editor preference overrides the environment", func(t *testing.T) {
+		t.Setenv("VISUAL", "nvim")
+		t.Setenv("EDITOR", "ed")
+		assert.Equal(t, "emacsclient -t", obsidian.PreferredEditor(obsidian.CliConfig{Editor: "emacsclient -t"}, true))
+	})
+
+	t.Run("Falls back to vim", func(t *testing.T) {
+		t.Setenv("VISUAL", "")
+		t.Setenv("EDITOR", "")
+		assert.Equal(t, "vim", obsidian.PreferredEditor(obsidian.CliConfig{}, true))
+	})
+}
+
+func TestEditorPreferences(t *testing.T) {
+	originalCliConfigPath := obsidian.CliConfigPath
+	defer func() { obsidian.CliConfigPath = originalCliConfigPath }()
+
+	t.Run("Saves the template and keeps the default vault", func(t *testing.T) {
+		// Arrange
+		mockCliConfigDir, mockCliConfigFile := mocks.CreateMockCliConfigDirectories(t)
+		obsidian.CliConfigPath = func() (string, string, error) {
+			return mockCliConfigDir, mockCliConfigFile, nil
+		}
+		err := os.WriteFile(mockCliConfigFile, []byte(`{"default_vault_name":"my-vault"}`), 0644)
+		assert.NoError(t, err)
+		// Act
+		err = obsidian.SetEditorCommand("{editor} +{line} {file}")
+		// Assert
+		assert.NoError(t, err)
+		cliConfig, err := obsidian.ReadCliConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, obsidian.CliConfig{DefaultVaultName: "my-vault", EditorCommand: "{editor} +{line} {file}"}, cliConfig)
+	})
+
+	t.Run("Template without the file", func(t *testing.T) {
+		err := obsidian.SetEditorCommand("{editor} +{line}")
+		assert.EqualError(t, err, obsidian.InvalidEditorCommandError)
+	})
+
+	t.Run("Saves the editor and wait flags", func(t *testing.T) {
+		// Arrange
+		mockCliConfigDir, mockCliConfigFile := mocks.CreateMockCliConfigDirectories(t)
+		obsidian.CliConfigPath = func() (string, string, error) {
+			return mockCliConfigDir, mockCliConfigFile, nil
+		}
+		// Act
+		err := obsidian.SetEditor("emacsclient -t")
+		assert.NoError(t, err)
+		err = obsidian.SetEditorWaitFlag("/usr/bin/gvim", "-f")
+		// Assert
+		assert.NoError(t, err)
+		cliConfig, err := obsidian.ReadCliConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, "emacsclient -t", cliConfig.Editor)
+		assert.Equal(t, map[string]string{"gvim": "-f"}, cliConfig.EditorWaitFlags)
+	})
+
+	t.Run("Editor with an unclosed quote", func(t *testing.T) {
+		err := obsidian.SetEditor(`"emacsclient -t`)
+		assert.EqualError(t, err, obsidian.EditorParseError)
+	})
+
+	t.Run("Opens the editor when the preferences cannot be parsed", func(t *testing.T) {
+		// Arrange
+		mockCliConfigDir, mockCliConfigFile := mocks.CreateMockCliConfigDirectories(t)
+		obsidian.CliConfigPath = func() (string, string, error) {
+			return mockCliConfigDir, mockCliConfigFile, nil
+		}
+		assert.NoError(t, os.WriteFile(mockCliConfigFile, []byte(`{"editor":`), 0644))
+		t.Setenv("VISUAL", "")
+		t.Setenv("EDITOR", "true")
+		// Act
+		err := obsidian.OpenInEditorAt(filepath.Join(t.TempDir(), "note.md"), 3, 0)
+		// Assert
+		assert.NoError(t, err)
+	})
+}
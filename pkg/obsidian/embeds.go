@@ -0,0 +1,133 @@
+package obsidian
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultEmbedDepth is how many levels of embeds inside embeds are resolved.
+const DefaultEmbedDepth = 5
+
+type EmbedOptions struct {
+	MaxDepth        int
+	SkipAttachments bool
+}
+
+type embedResolver struct {
+	vaultPath string
+	options   EmbedOptions
+}
+
+// ResolveEmbeds replaces the ![[embeds]] in the content of the note at
+// notePath with the notes, sections and blocks they point to, recursively.
+// Attachments such as images are replaced with their path in the vault, or
+// removed with options.SkipAttachments. Embeds that cannot be found, that
+// are nested too deep or that would embed themselves are left as they are.
+func ResolveEmbeds(vaultPath string, notePath string, content string, options EmbedOptions) string {
+	if options.MaxDepth <= 0 {
+		options.MaxDepth = DefaultEmbedDepth
+	}
+	resolver := embedResolver{vaultPath: vaultPath, options: options}
+	return resolver.resolve(notePath, content, []string{embedKey(notePath, "")})
+}
+
+func (r embedResolver) resolve(notePath string, content string, stack []string) string {
+	return mapProse(content, func(text string) string {
+		return ReplaceWikiLinks(text, func(link WikiLink, original string) string {
+			if !link.Embed {
+				return original
+			}
+			return r.embed(notePath, link, original, stack)
+		})
+	})
+}
+
+func (r embedResolver) embed(notePath string, link WikiLink, original string, stack []string) string {
+	target := notePath
+	if link.Target != "" {
+		var err error
+		target, err = ResolveNotePath(r.vaultPath, link.Target)
+		if err != nil {
+			return r.attachment(link, original)
+		}
+	}
+	if len(stack) > r.options.MaxDepth {
+		return original
+	}
+	key := embedKey(target, link.Subpath)
+	for _, parent := range stack {
+		if parent == key {
+			return original
+		}
+	}
+
+	content, err := os.ReadFile(target)
+	if err != nil {
+		return original
+	}
+	embedded := string(content)
+	if link.Subpath != "" {
+		embedded, err = ExtractSubpath(embedded, link.Subpath)
+		if err != nil {
+			return original
+		}
+	} else {
+		lines := strings.Split(embedded, "\n")
+		embedded = strings.Join(lines[frontmatterEnd(lines):], "\n")
+	}
+
+	embedded = r.resolve(target, embedded, append(stack, key))
+	return strings.Trim(embedded, "\r\n")
+}
+
+// attachment returns the vault relative path of an embedded file that is not
+// a note, e.g. an image or a PDF.
+func (r embedResolver) attachment(link WikiLink, original string) string {
+	if ext := filepath.Ext(link.Target); ext == "" || ext == ".md" {
+		return original
+	}
+	if r.options.SkipAttachments {
+		return ""
+	}
+	attachmentPath, ok := findVaultFile(r.vaultPath, link.Target)
+	if !ok {
+		return original
+	}
+	relPath, err := filepath.Rel(r.vaultPath, attachmentPath)
+	if err != nil {
+		return original
+	}
+	return filepath.ToSlash(relPath)
+}
+
+func embedKey(notePath string, subpath string) string {
+	return notePath + "#" + strings.ToLower(subpath)
+}
+
+// findVaultFile finds a file by its path from the vault root, falling back
+// to a match on the file name anywhere in the vault, like ResolveNotePath.
+func findVaultFile(vaultPath string, name string) (string, bool) {
+	fullPath := filepath.Join(vaultPath, name)
+	if info, err := os.Stat(fullPath); err == nil && !info.IsDir() {
+		return fullPath, true
+	}
+
+	var found string
+	base := filepath.Base(name)
+	_ = filepath.WalkDir(vaultPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() && strings.HasPrefix(d.Name(), ".") && path != vaultPath {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && d.Name() == base {
+			found = path
+			return errNoteFound
+		}
+		return nil
+	})
+	return found, found != ""
+}
@@ -0,0 +1,65 @@
+package obsidian_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func createEmbedsVault(t *testing.T) string {
+	t.Helper()
+	notes := map[string]string{
+		"Main.md":             "Intro\n![[Part]]\n```\n![[Part]]\n```\n",
+		"Part.md":             "---\ntitle: Part\n---\nPart text\n![[Deep#Section]]\n",
+		"Deep.md":             "# Section\nDeep text ^b1\n# Other\n",
+		"Loop.md":             "Loop\n![[Loop]]\n",
+		"attachments/pic.png": "png",
+	}
+	return mocks.CreateVaultFiles(t, notes)
+}
+
+func TestResolveEmbeds(t *testing.T) {
+	t.Run("Resolves nested embeds outside code", func(t *testing.T) {
+		// Arrange
+		vaultPath := createEmbedsVault(t)
+		content, _ := os.ReadFile(filepath.Join(vaultPath, "Main.md"))
+		// Act
+		resolved := obsidian.ResolveEmbeds(vaultPath, filepath.Join(vaultPath, "Main.md"), string(content), obsidian.EmbedOptions{})
+		// Assert
+		assert.Equal(t, "Intro\nPart text\n# Section\nDeep text ^b1\n```\n![[Part]]\n```\n", resolved)
+	})
+
+	t.Run("Stops at cycles", func(t *testing.T) {
+		// Arrange
+		vaultPath := createEmbedsVault(t)
+		// Act
+		resolved := obsidian.ResolveEmbeds(vaultPath, filepath.Join(vaultPath, "Loop.md"), "Loop\n![[Loop]]\n", obsidian.EmbedOptions{})
+		// Assert
+		assert.Equal(t, "Loop\n![[Loop]]\n", resolved)
+	})
+
+	t.Run("Stops at the depth limit", func(t *testing.T) {
+		// Arrange
+		vaultPath := createEmbedsVault(t)
+		// Act
+		resolved := obsidian.ResolveEmbeds(vaultPath, "", "![[Part]]", obsidian.EmbedOptions{MaxDepth: 1})
+		// Assert
+		assert.Equal(t, "Part text\n![[Deep#Section]]", resolved)
+	})
+
+	t.Run("Blocks, attachments and missing notes", func(t *testing.T) {
+		// Arrange
+		vaultPath := createEmbedsVault(t)
+		content := "![[Deep#^b1]] ![[pic.png]] ![[Missing]]"
+		// Act
+		resolved := obsidian.ResolveEmbeds(vaultPath, "", content, obsidian.EmbedOptions{})
+		skipped := obsidian.ResolveEmbeds(vaultPath, "", content, obsidian.EmbedOptions{SkipAttachments: true})
+		// Assert
+		assert.Equal(t, "Deep text attachments/pic.png ![[Missing]]", resolved)
+		assert.Equal(t, "Deep text  ![[Missing]]", skipped)
+	})
+}
@@ -0,0 +1,74 @@
+package obsidian
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ParseFrontmatter splits a note into its YAML frontmatter properties and its
+// body. A note without frontmatter has no properties.
+func ParseFrontmatter(content string) (map[string]interface{}, string, error) {
+	lines := strings.Split(content, "\n")
+	end := frontmatterEnd(lines)
+	if end == 0 {
+		return map[string]interface{}{}, content, nil
+	}
+
+	properties := map[string]interface{}{}
+	err := yaml.Unmarshal([]byte(strings.Join(lines[1:end-1], "\n")), &properties)
+	if err != nil {
+		return nil, "", errors.New(FrontmatterParseError)
+	}
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+	return properties, strings.Join(lines[end:], "\n"), nil
+}
+
+// FrontmatterString returns a frontmatter property as a string. Dates are
+// formatted as YYYY-MM-DD, or with the time when it is set.
+func FrontmatterString(properties map[string]interface{}, key string) string {
+	value, ok := properties[key]
+	if !ok || value == nil {
+		return ""
+	}
+	return strings.TrimSpace(yamlScalarString(value))
+}
+
+// FrontmatterList returns a frontmatter property that may be a single value
+// or a list, such as tags or aliases, as a list of strings.
+func FrontmatterList(properties map[string]interface{}, key string) []string {
+	var values []string
+	switch value := properties[key].(type) {
+	case nil:
+	case []interface{}:
+		for _, item := range value {
+			if item != nil {
+				values = append(values, strings.TrimSpace(yamlScalarString(item)))
+			}
+		}
+	case string:
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+	default:
+		values = append(values, yamlScalarString(value))
+	}
+	return values
+}
+
+func yamlScalarString(value interface{}) string {
+	if t, ok := value.(time.Time); ok {
+		if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+			return t.Format(DateLayout)
+		}
+		return t.Format("2006-01-02T15:04:05")
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,33 @@
+package obsidian_test
+
+import (
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFrontmatter(t *testing.T) {
+	t.Run("Parses properties and body", func(t *testing.T) {
+		// Act
+		properties, body, err := obsidian.ParseFrontmatter("---\ndate: 2024-01-10\ntags: [a, b]\naliases: Apollo\n---\n# Body\n")
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "# Body\n", body)
+		assert.Equal(t, "2024-01-10", obsidian.FrontmatterString(properties, "date"))
+		assert.Equal(t, []string{"a", "b"}, obsidian.FrontmatterList(properties, "tags"))
+		assert.Equal(t, []string{"Apollo"}, obsidian.FrontmatterList(properties, "aliases"))
+	})
+
+	t.Run("Note without frontmatter", func(t *testing.T) {
+		properties, body, err := obsidian.ParseFrontmatter("# Body\n")
+		assert.NoError(t, err)
+		assert.Empty(t, properties)
+		assert.Equal(t, "# Body\n", body)
+	})
+
+	t.Run("Invalid YAML", func(t *testing.T) {
+		_, _, err := obsidian.ParseFrontmatter("---\ntags: [a\n---\n")
+		assert.EqualError(t, err, obsidian.FrontmatterParseError)
+	})
+}
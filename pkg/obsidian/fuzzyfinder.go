@@ -7,8 +7,18 @@ import (
 
 type FuzzyFinder struct{}
 
+// previewOption is the option made by WithPreview.
+type previewOption func(i, width, height int) string
+
+// WithPreview shows the text preview returns for the item under the cursor
+// next to the list. i is -1 when no item matches the query.
+func WithPreview(preview func(i, width, height int) string) interface{} {
+	return previewOption(preview)
+}
+
 type FuzzyFinderManager interface {
 	Find(slice interface{}, itemFunc func(i int) string, opts ...interface{}) (int, error)
+	FindMulti(slice interface{}, itemFunc func(i int) string, opts ...interface{}) ([]int, error)
 }
 
 func (f *FuzzyFinder) Find(slice interface{}, itemFunc func(i int) string, opts ...interface{}) (int, error) {
@@ -19,9 +29,36 @@ func (f *FuzzyFinder) Find(slice interface{}, itemFunc func(i int) string, opts
 
 	index, err := fuzzyfinder.Find(items, func(i int) string {
 		return itemFunc(i)
-	})
+	}, finderOptions(opts)...)
 	if err != nil {
 		return -1, errors.New(NoteDoesNotExistError)
 	}
 	return index, nil
 }
+
+// FindMulti lets several items be marked with tab and returns their indexes.
+// Pressing enter without marking any returns the item under the cursor.
+func (f *FuzzyFinder) FindMulti(slice interface{}, itemFunc func(i int) string, opts ...interface{}) ([]int, error) {
+	items, ok := slice.([]string)
+	if !ok {
+		return nil, errors.New("invalid slice type, expected []string")
+	}
+
+	indexes, err := fuzzyfinder.FindMulti(items, func(i int) string {
+		return itemFunc(i)
+	}, finderOptions(opts)...)
+	if err != nil {
+		return nil, errors.New(NoteDoesNotExistError)
+	}
+	return indexes, nil
+}
+
+func finderOptions(opts []interface{}) []fuzzyfinder.Option {
+	var options []fuzzyfinder.Option
+	for _, opt := range opts {
+		if preview, ok := opt.(previewOption); ok {
+			options = append(options, fuzzyfinder.WithPreviewWindow(preview))
+		}
+	}
+	return options
+}
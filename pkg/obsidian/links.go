@@ -0,0 +1,139 @@
+package obsidian
+
+import (
+	"errors"
+	"io/fs"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var markdownLinkPattern = regexp.MustCompile(`!?\[[^\]]*\]\(<?([^)<>\s]+)>?(?:[ \t]+"[^"]*")?\)`)
+
+// NoteIndex resolves link targets to the files of a vault the way Obsidian
+// does: by path from the vault root, or else by file name.
+type NoteIndex struct {
+	files  []string
+	byPath map[string]string
+	byName map[string]string
+}
+
+// BuildNoteIndex lists the notes and attachments of the vault, leaving out
+// hidden files and folders. Paths are vault relative and use forward slashes.
+func BuildNoteIndex(vaultPath string) (NoteIndex, error) {
+	index := NoteIndex{byPath: map[string]string{}, byName: map[string]string{}}
+	err := filepath.WalkDir(vaultPath, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return errors.New(VaultAccessError)
+		}
+		if strings.HasPrefix(d.Name(), ".") && filePath != vaultPath {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(vaultPath, filePath)
+		if err != nil {
+			return errors.New(VaultAccessError)
+		}
+		index.files = append(index.files, filepath.ToSlash(relPath))
+		return nil
+	})
+	if err != nil {
+		return NoteIndex{}, err
+	}
+
+	// Shorter paths win when several files share a name, as in Obsidian.
+	sort.SliceStable(index.files, func(i, j int) bool {
+		return strings.Count(index.files[i], "/") < strings.Count(index.files[j], "/")
+	})
+	for _, file := range index.files {
+		index.byPath[strings.ToLower(file)] = file
+		name := strings.ToLower(path.Base(file))
+		if _, ok := index.byName[name]; !ok {
+			index.byName[name] = file
+		}
+	}
+	sort.Strings(index.files)
+	return index, nil
+}
+
+// Files returns the vault relative paths of all indexed files, sorted.
+func (i NoteIndex) Files() []string {
+	return i.files
+}
+
+// Resolve returns the file a link target in the note at from points to. A
+// target without an extension refers to a note. Targets starting with ./ or
+// ../ are relative to the folder of from.
+func (i NoteIndex) Resolve(from string, target string) (string, bool) {
+	target = strings.TrimSpace(filepath.ToSlash(target))
+	if target == "" {
+		return "", false
+	}
+	if strings.HasPrefix(target, "./") || strings.HasPrefix(target, "../") {
+		target = path.Join(path.Dir(from), target)
+	}
+	target = strings.TrimPrefix(target, "/")
+
+	for _, candidate := range []string{target + ".md", target} {
+		key := strings.ToLower(candidate)
+		if file, ok := i.byPath[key]; ok {
+			return file, true
+		}
+		if file, ok := i.byName[path.Base(key)]; ok && !strings.Contains(candidate, "/") {
+			return file, true
+		}
+	}
+	return "", false
+}
+
+// NoteLinks returns the targets of the wikilinks, embeds and Markdown links
+// to local files in a note, in order. Links in frontmatter properties count
+// too, while links in code do not. Subpaths are left out.
+func NoteLinks(content string) []string {
+	var targets []string
+	lines := strings.Split(content, "\n")
+	for _, link := range ParseWikiLinks(strings.Join(lines[:frontmatterEnd(lines)], "\n")) {
+		targets = append(targets, link.Target)
+	}
+	mapProse(content, func(text string) string {
+		for _, link := range ParseWikiLinks(text) {
+			targets = append(targets, link.Target)
+		}
+		for _, match := range markdownLinkPattern.FindAllStringSubmatch(text, -1) {
+			if target, ok := localLinkTarget(match[1]); ok {
+				targets = append(targets, target)
+			}
+		}
+		return text
+	})
+
+	var result []string
+	for _, target := range targets {
+		if target != "" {
+			result = append(result, target)
+		}
+	}
+	return result
+}
+
+// localLinkTarget returns the decoded file path of a Markdown link, or false
+// for web links and links within the same note.
+func localLinkTarget(destination string) (string, bool) {
+	if strings.Contains(destination, "://") || strings.HasPrefix(destination, "mailto:") || strings.HasPrefix(destination, "#") {
+		return "", false
+	}
+	destination, _, _ = strings.Cut(destination, "#")
+	decoded, err := url.PathUnescape(destination)
+	if err != nil {
+		return "", false
+	}
+	return RemoveMdSuffix(decoded), true
+}
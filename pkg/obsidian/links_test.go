@@ -0,0 +1,64 @@
+package obsidian_test
+
+import (
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoteLinks(t *testing.T) {
+	t.Run("Finds wikilinks, embeds and local Markdown links", func(t *testing.T) {
+		// Arrange
+		content := "---\nrelated: \"[[Meta]]\"\n---\n" +
+			"See [[One#Intro|one]] and ![[pic.png]].\n" +
+			"[Two](folder/Two%20Notes.md#part) and [web](https://example.com) and [here](#top)\n" +
+			"`[[Code]]`\n```\n[[Fenced]]\n```\n"
+		// Act
+		links := obsidian.NoteLinks(content)
+		// Assert
+		assert.Equal(t, []string{"Meta", "One", "pic.png", "folder/Two Notes"}, links)
+	})
+}
+
+func TestNoteIndexResolve(t *testing.T) {
+	// Arrange
+	vaultPath := mocks.CreateVaultFiles(t, map[string]string{
+		"One.md":           "",
+		"deep/One.md":      "",
+		"deep/Two.md":      "",
+		"img/pic.png":      "",
+		".obsidian/app.md": "",
+	})
+	index, err := obsidian.BuildNoteIndex(vaultPath)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		from   string
+		target string
+		file   string
+		found  bool
+	}{
+		{"Name resolves to shortest path", "deep/Two.md", "one", "One.md", true},
+		{"Path from vault root", "Two.md", "deep/One", "deep/One.md", true},
+		{"Relative path", "deep/Two.md", "./One", "deep/One.md", true},
+		{"Attachment by name", "One.md", "pic.png", "img/pic.png", true},
+		{"Missing file", "One.md", "Three", "", false},
+		{"Hidden folders are skipped", "One.md", "app", "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			file, found := index.Resolve(test.from, test.target)
+			// Assert
+			assert.Equal(t, test.found, found)
+			assert.Equal(t, test.file, file)
+		})
+	}
+
+	t.Run("Files are sorted", func(t *testing.T) {
+		assert.Equal(t, []string{"One.md", "deep/One.md", "deep/Two.md", "img/pic.png"}, index.Files())
+	})
+}
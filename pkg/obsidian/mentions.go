@@ -0,0 +1,200 @@
+package obsidian
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Mention is a plain-text occurrence of the title or an alias of a note that
+// is not linked to it.
+type Mention struct {
+	FilePath   string
+	LineNumber int
+	Line       string
+	Text       string
+	Name       string
+}
+
+// Links, URLs and tags already point somewhere, so mentions inside them are
+// not mentions.
+var mentionSkipPattern = regexp.MustCompile(`!?\[\[[^\]]*\]\]|!?\[[^\]]*\]\([^)]*\)|<?[a-zA-Z][a-zA-Z0-9+.-]*://[^\s>]*>?|#[\p{L}\p{N}_/\-]+`)
+
+// MentionNames returns the names a note goes by: its title, from the file
+// name, and the aliases in its frontmatter, without duplicates.
+func MentionNames(title string, content string) []string {
+	candidates := append([]string{title}, NoteAliases(content)...)
+
+	var names []string
+	seen := map[string]bool{}
+	for _, name := range candidates {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[strings.ToLower(name)] {
+			continue
+		}
+		seen[strings.ToLower(name)] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// FindMentions returns the unlinked mentions of names in a note. Names match
+// whole words regardless of case. The frontmatter, headings, code, links,
+// URLs and tags are skipped.
+func FindMentions(relPath string, content string, names []string) []Mention {
+	var mentions []Mention
+	mapMentions(relPath, content, names, func(mention Mention) string {
+		mentions = append(mentions, mention)
+		return mention.Text
+	})
+	return mentions
+}
+
+// LinkMentions turns the unlinked mentions of names in a note into links to
+// target, for each mention accept returns true for. A mention written
+// exactly as target becomes [[target]] and any other becomes
+// [[target|mention]] so the text reads the same. It returns the updated note
+// and the mentions linked.
+func LinkMentions(relPath string, content string, target string, names []string, accept func(Mention) bool) (string, []Mention) {
+	var linked []Mention
+	updated := mapMentions(relPath, content, names, func(mention Mention) string {
+		if accept != nil && !accept(mention) {
+			return mention.Text
+		}
+		linked = append(linked, mention)
+		link := WikiLink{Target: target, Alias: mentionAlias(mention.Text, target)}.String()
+		if strings.HasPrefix(strings.TrimSpace(mention.Line), "|") {
+			// The alias separator would end the table cell.
+			link = strings.Replace(link, "|", `\|`, 1)
+		}
+		return link
+	})
+	return updated, linked
+}
+
+func mentionAlias(text string, target string) string {
+	if text == target {
+		return ""
+	}
+	return text
+}
+
+// mapMentions calls fn on each unlinked mention of names in the prose of a
+// note, in order, and replaces the mention with what fn returns.
+func mapMentions(relPath string, content string, names []string, fn func(Mention) string) string {
+	pattern := mentionPattern(names)
+	if pattern == nil {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	fence := ""
+	for i := frontmatterEnd(lines); i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r")
+		if marker := codeFenceMarker(line); marker != "" {
+			if fence == "" {
+				fence = marker
+			} else if strings.HasPrefix(marker, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" || headingPattern.MatchString(line) {
+			continue
+		}
+
+		lines[i] = mapOutsideInlineCode(lines[i], func(text string) string {
+			return mapOutsideSkipped(text, func(text string) string {
+				return replaceMentions(pattern, text, func(match string) string {
+					return fn(Mention{
+						FilePath:   relPath,
+						LineNumber: i + 1,
+						Line:       line,
+						Text:       match,
+						Name:       mentionName(names, match),
+					})
+				})
+			})
+		})
+	}
+	return strings.Join(lines, "\n")
+}
+
+// mentionPattern matches any of names, trying the longest first so that an
+// alias such as "Project Apollo" wins over "Apollo".
+func mentionPattern(names []string) *regexp.Regexp {
+	var quoted []string
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			quoted = append(quoted, regexp.QuoteMeta(name))
+		}
+	}
+	if len(quoted) == 0 {
+		return nil
+	}
+	sort.SliceStable(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+	return regexp.MustCompile(`(?i)` + strings.Join(quoted, "|"))
+}
+
+func mentionName(names []string, match string) string {
+	for _, name := range names {
+		if strings.EqualFold(strings.TrimSpace(name), match) {
+			return name
+		}
+	}
+	return match
+}
+
+func mapOutsideSkipped(text string, fn func(string) string) string {
+	var b strings.Builder
+	start := 0
+	for _, loc := range mentionSkipPattern.FindAllStringIndex(text, -1) {
+		b.WriteString(fn(text[start:loc[0]]))
+		b.WriteString(text[loc[0]:loc[1]])
+		start = loc[1]
+	}
+	b.WriteString(fn(text[start:]))
+	return b.String()
+}
+
+// replaceMentions replaces the matches of pattern that stand as whole words.
+// A match inside a longer word is skipped and the search goes on from its
+// next character, so "Go" is still found in "Gopher Go".
+func replaceMentions(pattern *regexp.Regexp, text string, fn func(string) string) string {
+	var b strings.Builder
+	start, offset := 0, 0
+	for offset < len(text) {
+		loc := pattern.FindStringIndex(text[offset:])
+		if loc == nil {
+			break
+		}
+		from, to := offset+loc[0], offset+loc[1]
+		if !isWordBoundary(text, from) || !isWordBoundary(text, to) {
+			_, size := utf8.DecodeRuneInString(text[from:])
+			offset = from + size
+			continue
+		}
+		b.WriteString(text[start:from])
+		b.WriteString(fn(text[from:to]))
+		start, offset = to, to
+	}
+	b.WriteString(text[start:])
+	return b.String()
+}
+
+// isWordBoundary reports whether position i of text is not between two word
+// characters.
+func isWordBoundary(text string, i int) bool {
+	if i == 0 || i == len(text) {
+		return true
+	}
+	before, _ := utf8.DecodeLastRuneInString(text[:i])
+	after, _ := utf8.DecodeRuneInString(text[i:])
+	return !isWordRune(before) || !isWordRune(after)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
@@ -0,0 +1,78 @@
+package obsidian_test
+
+import (
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMentionNames(t *testing.T) {
+	// Act
+	names := obsidian.MentionNames("Apollo", "---\naliases: [Project Apollo, apollo]\n---\n")
+	// Assert
+	assert.Equal(t, []string{"Apollo", "Project Apollo"}, names)
+}
+
+func TestFindMentions(t *testing.T) {
+	names := []string{"Apollo", "Project Apollo"}
+	tests := []struct {
+		name    string
+		content string
+		found   []string
+	}{
+		{"Whole words in any case", "apollo and Apollos and APOLLO.", []string{"apollo", "APOLLO"}},
+		{"Longest name wins", "Project Apollo is on", []string{"Project Apollo"}},
+		{"Links are skipped", "[[Apollo]] and [Apollo](Apollo.md) and https://apollo.com/Apollo", nil},
+		{"Tags are skipped", "#Apollo and #apollo/launch", nil},
+		{"Code is skipped", "`Apollo`\n```\nApollo\n```\n", nil},
+		{"Headings are skipped", "## Apollo\nApollo", []string{"Apollo"}},
+		{"Frontmatter is skipped", "---\ntitle: Apollo\n---\n", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			mentions := obsidian.FindMentions("note.md", test.content, names)
+			// Assert
+			var found []string
+			for _, mention := range mentions {
+				found = append(found, mention.Text)
+			}
+			assert.Equal(t, test.found, found)
+		})
+	}
+
+	t.Run("Records the location", func(t *testing.T) {
+		mentions := obsidian.FindMentions("notes/a.md", "# Title\nAbout project apollo\n", names)
+		assert.Equal(t, []obsidian.Mention{{
+			FilePath:   "notes/a.md",
+			LineNumber: 2,
+			Line:       "About project apollo",
+			Text:       "project apollo",
+			Name:       "Project Apollo",
+		}}, mentions)
+	})
+}
+
+func TestLinkMentions(t *testing.T) {
+	t.Run("Links with an alias unless written as the target", func(t *testing.T) {
+		// Act
+		updated, linked := obsidian.LinkMentions("a.md", "Apollo, Project Apollo and apollo\n", "Apollo", []string{"Apollo", "Project Apollo"}, nil)
+		// Assert
+		assert.Equal(t, "[[Apollo]], [[Apollo|Project Apollo]] and [[Apollo|apollo]]\n", updated)
+		assert.Len(t, linked, 3)
+	})
+
+	t.Run("Only links accepted mentions", func(t *testing.T) {
+		updated, linked := obsidian.LinkMentions("a.md", "Apollo on line 1\nApollo on line 2\n", "Apollo", []string{"Apollo"}, func(m obsidian.Mention) bool {
+			return m.LineNumber == 2
+		})
+		assert.Equal(t, "Apollo on line 1\n[[Apollo]] on line 2\n", updated)
+		assert.Len(t, linked, 1)
+	})
+
+	t.Run("Escapes the alias separator in tables", func(t *testing.T) {
+		updated, _ := obsidian.LinkMentions("a.md", "| apollo | 1 |\n", "Apollo", []string{"Apollo"}, nil)
+		assert.Equal(t, "| [[Apollo\\|apollo]] | 1 |\n", updated)
+	})
+}
@@ -0,0 +1,351 @@
+package obsidian
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// momentTokens lists the moment.js format tokens we understand, longest first
+// so that e.g. "YYYY" wins over "YY".
+var momentTokens = []string{
+	"YYYY", "YY", "Y",
+	"GGGG", "GG", "gggg", "gg",
+	"Qo", "Q",
+	"MMMM", "MMM", "MMo", "MM", "Mo", "M",
+	"DDDD", "DDDo", "DDD", "DD", "Do", "D",
+	"dddd", "ddd", "dd", "do", "d",
+	"E", "e",
+	"WW", "Wo", "W", "ww", "wo", "w",
+	"HH", "H", "hh", "h", "kk", "k",
+	"mm", "m", "ss", "s",
+	"SSS", "SS", "S",
+	"A", "a",
+	"ZZ", "Z",
+	"X", "x",
+}
+
+// FormatMomentDate formats t using a moment.js format string, which is what
+// Obsidian stores in its settings (e.g. "YYYY-MM-DD" or "dddd, MMMM Do YYYY").
+// Text wrapped in square brackets is copied verbatim.
+func FormatMomentDate(t time.Time, format string) string {
+	var b strings.Builder
+	for i := 0; i < len(format); {
+		if format[i] == '[' {
+			end := strings.IndexByte(format[i:], ']')
+			if end != -1 {
+				b.WriteString(format[i+1 : i+end])
+				i += end + 1
+				continue
+			}
+		}
+
+		token := matchMomentToken(format[i:])
+		if token == "" {
+			b.WriteByte(format[i])
+			i++
+			continue
+		}
+		b.WriteString(formatMomentToken(t, token))
+		i += len(token)
+	}
+	return b.String()
+}
+
+func matchMomentToken(s string) string {
+	for _, token := range momentTokens {
+		if strings.HasPrefix(s, token) {
+			return token
+		}
+	}
+	return ""
+}
+
+func formatMomentToken(t time.Time, token string) string {
+	switch token {
+	case "YYYY":
+		return fmt.Sprintf("%04d", t.Year())
+	case "YY":
+		return fmt.Sprintf("%02d", t.Year()%100)
+	case "Y":
+		return strconv.Itoa(t.Year())
+	case "GGGG":
+		year, _ := t.ISOWeek()
+		return fmt.Sprintf("%04d", year)
+	case "GG":
+		year, _ := t.ISOWeek()
+		return fmt.Sprintf("%02d", year%100)
+	case "gggg":
+		year, _ := localeWeek(t)
+		return fmt.Sprintf("%04d", year)
+	case "gg":
+		year, _ := localeWeek(t)
+		return fmt.Sprintf("%02d", year%100)
+	case "Q":
+		return strconv.Itoa(quarter(t))
+	case "Qo":
+		return ordinal(quarter(t))
+	case "MMMM":
+		return t.Month().String()
+	case "MMM":
+		return t.Month().String()[:3]
+	case "MM":
+		return fmt.Sprintf("%02d", int(t.Month()))
+	case "MMo", "Mo":
+		return ordinal(int(t.Month()))
+	case "M":
+		return strconv.Itoa(int(t.Month()))
+	case "DDDD":
+		return fmt.Sprintf("%03d", t.YearDay())
+	case "DDDo":
+		return ordinal(t.YearDay())
+	case "DDD":
+		return strconv.Itoa(t.YearDay())
+	case "DD":
+		return fmt.Sprintf("%02d", t.Day())
+	case "Do":
+		return ordinal(t.Day())
+	case "D":
+		return strconv.Itoa(t.Day())
+	case "dddd":
+		return t.Weekday().String()
+	case "ddd":
+		return t.Weekday().String()[:3]
+	case "dd":
+		return t.Weekday().String()[:2]
+	case "do":
+		return ordinal(int(t.Weekday()))
+	case "d", "e":
+		return strconv.Itoa(int(t.Weekday()))
+	case "E":
+		return strconv.Itoa(isoWeekday(t))
+	case "WW":
+		_, week := t.ISOWeek()
+		return fmt.Sprintf("%02d", week)
+	case "Wo":
+		_, week := t.ISOWeek()
+		return ordinal(week)
+	case "W":
+		_, week := t.ISOWeek()
+		return strconv.Itoa(week)
+	case "ww":
+		_, week := localeWeek(t)
+		return fmt.Sprintf("%02d", week)
+	case "wo":
+		_, week := localeWeek(t)
+		return ordinal(week)
+	case "w":
+		_, week := localeWeek(t)
+		return strconv.Itoa(week)
+	case "HH":
+		return fmt.Sprintf("%02d", t.Hour())
+	case "H":
+		return strconv.Itoa(t.Hour())
+	case "hh":
+		return fmt.Sprintf("%02d", twelveHour(t))
+	case "h":
+		return strconv.Itoa(twelveHour(t))
+	case "kk":
+		return fmt.Sprintf("%02d", kHour(t))
+	case "k":
+		return strconv.Itoa(kHour(t))
+	case "mm":
+		return fmt.Sprintf("%02d", t.Minute())
+	case "m":
+		return strconv.Itoa(t.Minute())
+	case "ss":
+		return fmt.Sprintf("%02d", t.Second())
+	case "s":
+		return strconv.Itoa(t.Second())
+	case "SSS":
+		return fmt.Sprintf("%03d", t.Nanosecond()/int(time.Millisecond))
+	case "SS":
+		return fmt.Sprintf("%02d", t.Nanosecond()/int(10*time.Millisecond))
+	case "S":
+		return strconv.Itoa(t.Nanosecond() / int(100*time.Millisecond))
+	case "A":
+		return t.Format("PM")
+	case "a":
+		return t.Format("pm")
+	case "ZZ":
+		return t.Format("-0700")
+	case "Z":
+		return t.Format("-07:00")
+	case "X":
+		return strconv.FormatInt(t.Unix(), 10)
+	case "x":
+		return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10)
+	}
+	return token
+}
+
+func quarter(t time.Time) int {
+	return (int(t.Month())-1)/3 + 1
+}
+
+func twelveHour(t time.Time) int {
+	h := t.Hour() % 12
+	if h == 0 {
+		return 12
+	}
+	return h
+}
+
+func kHour(t time.Time) int {
+	if t.Hour() == 0 {
+		return 24
+	}
+	return t.Hour()
+}
+
+func isoWeekday(t time.Time) int {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+	return int(t.Weekday())
+}
+
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 100 {
+	case 11, 12, 13:
+	default:
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// localeWeek returns the week-based year and week number using moment's
+// default "en" locale rules: weeks start on Sunday and the week containing
+// January 1st is the first week of the year.
+func localeWeek(t time.Time) (int, int) {
+	const dow, doy = 0, 6
+	year := t.Year()
+	days := t.YearDay() - firstWeekOffset(year, dow, doy) - 1
+	week := days/7 + 1
+	if days < 0 {
+		// Days before the first week belong to the last week of last year.
+		week = 0
+	}
+	if week < 1 {
+		return year - 1, week + weeksInYear(year-1, dow, doy)
+	}
+	if weeks := weeksInYear(year, dow, doy); week > weeks {
+		return year + 1, week - weeks
+	}
+	return year, week
+}
+
+func firstWeekOffset(year, dow, doy int) int {
+	fwd := 7 + dow - doy
+	fwdlw := (7 + int(time.Date(year, time.January, fwd, 0, 0, 0, 0, time.UTC).Weekday()) - dow) % 7
+	return -fwdlw + fwd - 1
+}
+
+func weeksInYear(year, dow, doy int) int {
+	daysInYear := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	return (daysInYear - firstWeekOffset(year, dow, doy) + firstWeekOffset(year+1, dow, doy)) / 7
+}
+
+// ParseMomentDate parses value using a moment.js format, the reverse of
+// FormatMomentDate. The year, month and day are read from the value; the
+// other tokens, such as the weekday, only have to be consistent with it.
+func ParseMomentDate(value string, format string) (time.Time, error) {
+	var pattern strings.Builder
+	var fields []string
+	pattern.WriteString("^")
+	for i := 0; i < len(format); {
+		if format[i] == '[' {
+			end := strings.IndexByte(format[i:], ']')
+			if end != -1 {
+				pattern.WriteString(regexp.QuoteMeta(format[i+1 : i+end]))
+				i += end + 1
+				continue
+			}
+		}
+
+		token := matchMomentToken(format[i:])
+		if token == "" {
+			pattern.WriteString(regexp.QuoteMeta(format[i : i+1]))
+			i++
+			continue
+		}
+		switch token {
+		case "YYYY":
+			pattern.WriteString(`(\d{4})`)
+		case "YY", "MM", "DD":
+			pattern.WriteString(`(\d{2})`)
+		case "M", "D":
+			pattern.WriteString(`(\d{1,2})`)
+		case "Mo", "Do":
+			pattern.WriteString(`(\d{1,2})(?:st|nd|rd|th)`)
+		case "MMMM", "MMM":
+			pattern.WriteString(`([A-Za-z]+)`)
+		case "DDDD", "DDD":
+			pattern.WriteString(`(\d{1,3})`)
+		default:
+			pattern.WriteString(`(.+?)`)
+		}
+		fields = append(fields, token)
+		i += len(token)
+	}
+	pattern.WriteString("$")
+
+	match := regexp.MustCompile(pattern.String()).FindStringSubmatch(value)
+	if match == nil {
+		return time.Time{}, errors.New(InvalidDateError)
+	}
+
+	year, month, day, yearDay := -1, time.Month(0), 0, 0
+	for i, token := range fields {
+		text := match[i+1]
+		number, _ := strconv.Atoi(text)
+		switch token {
+		case "YYYY":
+			year = number
+		case "YY":
+			year = 2000 + number
+		case "M", "MM", "Mo":
+			month = time.Month(number)
+		case "MMMM", "MMM":
+			for m := time.January; m <= time.December; m++ {
+				if strings.EqualFold(m.String(), text) || strings.EqualFold(m.String()[:3], text) {
+					month = m
+				}
+			}
+		case "D", "DD", "Do":
+			day = number
+		case "DDDD", "DDD":
+			yearDay = number
+		}
+	}
+
+	var date time.Time
+	switch {
+	case year == -1:
+		return time.Time{}, errors.New(InvalidDateError)
+	case yearDay > 0:
+		date = time.Date(year, time.January, yearDay, 0, 0, 0, 0, time.Local)
+	case month >= time.January && month <= time.December && day > 0:
+		date = time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+	default:
+		return time.Time{}, errors.New(InvalidDateError)
+	}
+
+	// Round trip to reject impossible dates such as 02-31 and values whose
+	// other tokens, like the weekday, do not match the date.
+	if FormatMomentDate(date, format) != value {
+		return time.Time{}, errors.New(InvalidDateError)
+	}
+	return date, nil
+}
@@ -0,0 +1,119 @@
+package obsidian_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatMomentDate(t *testing.T) {
+	date := time.Date(2024, time.January, 2, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		testName string
+		format   string
+		want     string
+	}{
+		{"Default daily note format", "YYYY-MM-DD", "2024-01-02"},
+		{"Long month and weekday", "dddd, MMMM Do YYYY", "Tuesday, January 2nd 2024"},
+		{"Short names", "ddd MMM D YY", "Tue Jan 2 24"},
+		{"Nested folders", "YYYY/MM/YYYY-MM-DD", "2024/01/2024-01-02"},
+		{"Escaped text", "[Week] W [of] YYYY", "Week 1 of 2024"},
+		{"24 hour time", "HH:mm:ss", "15:04:05"},
+		{"12 hour time", "h:mm A", "3:04 PM"},
+		{"Day of year and quarter", "DDDD Q", "002 1"},
+		{"Unknown characters are kept", "YYYY_MM_DD!", "2024_01_02!"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			// Act
+			got := obsidian.FormatMomentDate(date, test.format)
+			// Assert
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("Ordinals", func(t *testing.T) {
+		for day, want := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 21: "21st", 22: "22nd", 23: "23rd"} {
+			got := obsidian.FormatMomentDate(time.Date(2024, time.May, day, 0, 0, 0, 0, time.UTC), "Do")
+			assert.Equal(t, want, got)
+		}
+	})
+
+	t.Run("Locale and ISO weeks", func(t *testing.T) {
+		// Sunday 2023-01-01 starts locale week 1 but is in ISO week 52 of 2022.
+		sunday := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+		assert.Equal(t, "2023-01", obsidian.FormatMomentDate(sunday, "gggg-ww"))
+		assert.Equal(t, "2022-52", obsidian.FormatMomentDate(sunday, "GGGG-WW"))
+
+		// Friday 2021-12-31 shares a week with January 1st 2022.
+		friday := time.Date(2021, time.December, 31, 0, 0, 0, 0, time.UTC)
+		assert.Equal(t, "2022-01", obsidian.FormatMomentDate(friday, "gggg-ww"))
+	})
+}
+
+func TestParseDateArgument(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 8, 15, 0, 0, time.UTC)
+	tests := []struct {
+		testName string
+		value    string
+		want     string
+	}{
+		{"Empty defaults to today", "", "2024-03-01"},
+		{"Today", "today", "2024-03-01"},
+		{"Yesterday crosses month", "yesterday", "2024-02-29"},
+		{"Tomorrow", "Tomorrow", "2024-03-02"},
+		{"Explicit date", "2023-12-24", "2023-12-24"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			// Act
+			got, err := obsidian.ParseDateArgument(test.value, now)
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got.Format(obsidian.DateLayout))
+			assert.Equal(t, 8, got.Hour(), "Expected time of day to be kept")
+		})
+	}
+
+	t.Run("Invalid date", func(t *testing.T) {
+		// Act
+		_, err := obsidian.ParseDateArgument("24/12/2023", now)
+		// Assert
+		assert.Equal(t, obsidian.InvalidDateError, err.Error())
+	})
+}
+
+func TestParseMomentDate(t *testing.T) {
+	tests := []struct {
+		value  string
+		format string
+		want   time.Time
+	}{
+		{"2024-03-05", "YYYY-MM-DD", time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local)},
+		{"2024/03/2024-03-05", "YYYY/MM/YYYY-MM-DD", time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local)},
+		{"Tuesday, March 5th 2024", "dddd, MMMM Do YYYY", time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local)},
+		{"Log 5.3.24", "[Log] D.M.YY", time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local)},
+	}
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			got, err := obsidian.ParseMomentDate(test.value, test.format)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("Rejects values that do not match", func(t *testing.T) {
+		for value, format := range map[string]string{
+			"2024-02-31":             "YYYY-MM-DD",
+			"Meeting notes":          "YYYY-MM-DD",
+			"Monday, March 5th 2024": "dddd, MMMM Do YYYY",
+		} {
+			_, err := obsidian.ParseMomentDate(value, format)
+			assert.Error(t, err, value)
+		}
+	})
+}
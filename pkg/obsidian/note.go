@@ -1,7 +1,6 @@
 package obsidian
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -57,51 +56,68 @@ func (m *Note) Delete(path string) error {
 }
 
 func (m *Note) GetContents(vaultPath string, noteName string) (string, error) {
+	notePath, err := ResolveNotePath(vaultPath, noteName)
+	if err != nil {
+		return "", err
+	}
+
+	file, err := os.Open(notePath)
+	if err != nil {
+		return "", errors.New(VaultReadError)
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return "", errors.New(VaultReadError)
+	}
+
+	return string(content), nil
+}
+
+var errNoteFound = errors.New("note found")
+
+// ResolveNotePath finds a note by its path from the vault root, falling back
+// to a match on the file name anywhere in the vault. It returns the full path.
+func ResolveNotePath(vaultPath string, noteName string) (string, error) {
 	note := AddMdSuffix(noteName)
 
+	// Check for full path match first
+	fullPath := filepath.Join(vaultPath, note)
+	if info, err := os.Stat(fullPath); err == nil && !info.IsDir() {
+		return fullPath, nil
+	}
+
+	// Fall back to basename match for backward compatibility
 	var notePath string
 	err := filepath.WalkDir(vaultPath, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
-			return err // Continue to the next path if there's an error
+			return err
 		}
 		if d.IsDir() {
 			return nil // Skip directories
 		}
-
-		// Check for full path match first
-		relPath, err := filepath.Rel(vaultPath, path)
-		if err == nil && relPath == note {
-			notePath = path
-			return filepath.SkipDir
-		}
-
-		// Fall back to basename match for backward compatibility
 		if filepath.Base(path) == note {
 			notePath = path
-			return filepath.SkipDir
+			return errNoteFound
 		}
 		return nil
 	})
 
-	if err != nil || notePath == "" {
+	if (err != nil && err != errNoteFound) || notePath == "" {
 		return "", errors.New(NoteDoesNotExistError)
 	}
-
-	file, err := os.Open(notePath)
-	if err != nil {
-		return "", errors.New(VaultReadError)
-	}
-	defer file.Close()
-
-	content, err := io.ReadAll(file)
-	if err != nil {
-		return "", errors.New(VaultReadError)
-	}
-
-	return string(content), nil
+	return notePath, nil
 }
 
+// UpdateLinks points the wikilinks and embeds to oldNoteName at newNoteName
+// in every note of the vault, keeping their #heading or #^block subpath and
+// their alias. Links using the note's path keep using a path.
 func (m *Note) UpdateLinks(vaultPath string, oldNoteName string, newNoteName string) error {
+	oldPath := RemoveMdSuffix(filepath.ToSlash(oldNoteName))
+	newPath := RemoveMdSuffix(filepath.ToSlash(newNoteName))
+	oldName, newName := filepath.Base(oldPath), filepath.Base(newPath)
+
 	err := filepath.Walk(vaultPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return errors.New(VaultAccessError)
@@ -116,20 +132,26 @@ func (m *Note) UpdateLinks(vaultPath string, oldNoteName string, newNoteName str
 			return errors.New(VaultReadError)
 		}
 
-		oldNoteLinkTexts := GenerateNoteLinkTexts(oldNoteName)
-		newNoteLinkTexts := GenerateNoteLinkTexts(newNoteName)
-
-		updatedContent := ReplaceContent(originalContent, map[string]string{
-			oldNoteLinkTexts[0]: newNoteLinkTexts[0],
-			oldNoteLinkTexts[1]: newNoteLinkTexts[1],
-			oldNoteLinkTexts[2]: newNoteLinkTexts[2],
+		updatedContent := ReplaceWikiLinks(string(originalContent), func(link WikiLink, original string) string {
+			// Links in tables escape the | before their alias.
+			target := strings.TrimSuffix(link.Target, "\\")
+			escape := link.Target[len(target):]
+			switch name := RemoveMdSuffix(target); {
+			case strings.EqualFold(name, oldPath):
+				link.Target = newPath + escape
+			case strings.EqualFold(name, oldName):
+				link.Target = newName + escape
+			default:
+				return original
+			}
+			return link.String()
 		})
 
-		if bytes.Equal(originalContent, updatedContent) {
+		if updatedContent == string(originalContent) {
 			return nil
 		}
 
-		err = os.WriteFile(path, updatedContent, info.Mode())
+		err = os.WriteFile(path, []byte(updatedContent), info.Mode())
 		if err != nil {
 			return errors.New(VaultWriteError)
 		}
@@ -141,6 +163,70 @@ func (m *Note) UpdateLinks(vaultPath string, oldNoteName string, newNoteName str
 	return nil
 }
 
+// UpdateHeadingLinks points the links to a heading of a note at newHeading
+// in every note of the vault, including [[#heading]] links inside the note
+// itself. content is the note as it was before the heading was renamed, and
+// heading is the renamed one. A link is only changed where its subpath leads
+// to that heading, so [[Note#B#Old]] is kept when the Old under A is
+// renamed. Aliases are kept.
+func UpdateHeadingLinks(vaultPath string, noteName string, content string, heading Heading, newHeading string) error {
+	notePath, err := ResolveNotePath(vaultPath, noteName)
+	if err != nil {
+		return err
+	}
+	relPath, err := filepath.Rel(vaultPath, notePath)
+	if err != nil {
+		return errors.New(VaultAccessError)
+	}
+	linkPath := RemoveMdSuffix(filepath.ToSlash(relPath))
+	linkName := filepath.Base(linkPath)
+	headings := ParseHeadings(content)
+	lineCount := strings.Count(content, "\n") + 1
+
+	return WalkNotes(vaultPath, func(path string, noteContent []byte) error {
+		inNote := path == relPath
+		updated := ReplaceWikiLinks(string(noteContent), func(link WikiLink, original string) string {
+			target := RemoveMdSuffix(strings.TrimSuffix(link.Target, "\\"))
+			pointsToNote := strings.EqualFold(target, linkPath) || strings.EqualFold(target, linkName) || (target == "" && inNote)
+			if !pointsToNote || link.Subpath == "" || strings.HasPrefix(link.Subpath, "^") {
+				return original
+			}
+			// Nested subpaths such as Setup#Linux name headings on the way.
+			parts := strings.Split(link.Subpath, "#")
+			chain, ok := resolveSubpath(headings, parts, lineCount)
+			if !ok {
+				return original
+			}
+			changed := false
+			for i, found := range chain {
+				if found.LineNumber == heading.LineNumber {
+					parts[i] = newHeading
+					changed = true
+				}
+			}
+			if !changed {
+				return original
+			}
+			link.Subpath = strings.Join(parts, "#")
+			return link.String()
+		})
+		if updated == string(noteContent) {
+			return nil
+		}
+
+		fullPath := filepath.Join(vaultPath, path)
+		info, err := os.Stat(fullPath)
+		if err != nil {
+			return errors.New(VaultReadError)
+		}
+		err = os.WriteFile(fullPath, []byte(updated), info.Mode())
+		if err != nil {
+			return errors.New(VaultWriteError)
+		}
+		return nil
+	})
+}
+
 func (m *Note) GetNotesList(vaultPath string) ([]string, error) {
 	var notes []string
 	err := filepath.WalkDir(vaultPath, func(path string, d fs.DirEntry, err error) error {
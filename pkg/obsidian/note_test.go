@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Yakitrak/obsidian-cli/mocks"
 	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
 	"github.com/stretchr/testify/assert"
 )
@@ -135,6 +136,31 @@ func TestNote_GetContents(t *testing.T) {
 	})
 }
 
+func TestResolveNotePath(t *testing.T) {
+	t.Run("Prefers full path over file name match", func(t *testing.T) {
+		// Arrange
+		vaultPath := mocks.CreateVaultFiles(t, map[string]string{
+			"a/note.md": "",
+			"b/note.md": "",
+		})
+		// Act
+		byPath, err1 := obsidian.ResolveNotePath(vaultPath, "b/note")
+		byName, err2 := obsidian.ResolveNotePath(vaultPath, "note.md")
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Equal(t, filepath.Join(vaultPath, "b", "note.md"), byPath)
+		assert.Equal(t, filepath.Join(vaultPath, "a", "note.md"), byName)
+	})
+
+	t.Run("Missing note", func(t *testing.T) {
+		// Act
+		_, err := obsidian.ResolveNotePath(t.TempDir(), "missing")
+		// Assert
+		assert.Equal(t, obsidian.NoteDoesNotExistError, err.Error())
+	})
+}
+
 func TestMoveNote(t *testing.T) {
 	originalContent := "This is the original content."
 
@@ -253,6 +279,21 @@ func TestUpdateNoteLinks(t *testing.T) {
 		}
 	})
 
+	t.Run("Keeps block references, embeds and paths", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		notePath := filepath.Join(tmpDir, "links.md")
+		original := "[[oldNote#^abc123]] ![[oldNote#^abc123|quote]] [[folder/oldNote]] | [[oldnote\\|alias]] | [[oldNoteX]]"
+		assert.NoError(t, os.WriteFile(notePath, []byte(original), 0644))
+		noteManager := obsidian.Note{}
+		// Act
+		err := noteManager.UpdateLinks(tmpDir, "folder/oldNote", "archive/newNote")
+		// Assert
+		assert.NoError(t, err)
+		updated, _ := os.ReadFile(notePath)
+		assert.Equal(t, "[[newNote#^abc123]] ![[newNote#^abc123|quote]] [[archive/newNote]] | [[newNote\\|alias]] | [[oldNoteX]]", string(updated))
+	})
+
 	t.Run("Error on incorrect vault", func(t *testing.T) {
 		// Arrange
 		noteManager := obsidian.Note{}
@@ -283,6 +324,57 @@ func TestUpdateNoteLinks(t *testing.T) {
 	})
 }
 
+func TestUpdateHeadingLinks(t *testing.T) {
+	guide := "## A\n### Old\nSee [[#Old]], [[#A#Old]] and [[#Other]]\n## B\n### Old\n"
+	headings := obsidian.ParseHeadings(guide)
+
+	t.Run("Updates links to the heading across the vault", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		assert.NoError(t, os.Mkdir(filepath.Join(tmpDir, "docs"), 0755))
+		notes := map[string]string{
+			"docs/Guide.md": guide,
+			"links.md":      "[[Guide#Old]] [[docs/Guide#old|alias]] [[Guide#A#Old]] [[Guide#B#Old]] [[Guide#^old]] [[Other#Old]]\n",
+			"other.md":      "[[#Old]]\n",
+		}
+		for name, content := range notes {
+			assert.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644))
+		}
+		// Act
+		err := obsidian.UpdateHeadingLinks(tmpDir, "Guide", guide, headings[1], "New")
+		// Assert
+		assert.NoError(t, err)
+		expected := map[string]string{
+			"docs/Guide.md": "## A\n### Old\nSee [[#New]], [[#A#New]] and [[#Other]]\n## B\n### Old\n",
+			"links.md":      "[[Guide#New]] [[docs/Guide#New|alias]] [[Guide#A#New]] [[Guide#B#Old]] [[Guide#^old]] [[Other#Old]]\n",
+			"other.md":      "[[#Old]]\n",
+		}
+		for name, content := range expected {
+			updated, _ := os.ReadFile(filepath.Join(tmpDir, name))
+			assert.Equal(t, content, string(updated), name)
+		}
+	})
+
+	t.Run("Only updates nested links to a later heading with the same text", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		links := "[[Guide#Old]] [[Guide#A#Old]] [[Guide#B#Old|b]] [[Guide#B]]\n"
+		assert.NoError(t, os.WriteFile(filepath.Join(tmpDir, "Guide.md"), []byte(guide), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(tmpDir, "links.md"), []byte(links), 0644))
+		// Act
+		err := obsidian.UpdateHeadingLinks(tmpDir, "Guide", guide, headings[3], "New")
+		// Assert
+		assert.NoError(t, err)
+		updated, _ := os.ReadFile(filepath.Join(tmpDir, "links.md"))
+		assert.Equal(t, "[[Guide#Old]] [[Guide#A#Old]] [[Guide#B#New|b]] [[Guide#B]]\n", string(updated))
+	})
+
+	t.Run("Missing note", func(t *testing.T) {
+		err := obsidian.UpdateHeadingLinks(t.TempDir(), "Guide", guide, headings[1], "New")
+		assert.EqualError(t, err, obsidian.NoteDoesNotExistError)
+	})
+}
+
 func TestUpdateLinks_PreservesTimestamps(t *testing.T) {
 	t.Run("Only writes files with actual link changes", func(t *testing.T) {
 		// Arrange
@@ -0,0 +1,81 @@
+package obsidian
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	previewHighlight = "\x1b[1;33m"
+	previewDim       = "\x1b[2m"
+	previewReset     = "\x1b[0m"
+)
+
+// NotePreview returns the start of a note for the preview window of the
+// fuzzy finder, as many lines as fit in height. It returns "" if the note
+// cannot be read.
+func NotePreview(vaultPath string, relPath string, height int) string {
+	content, err := os.ReadFile(filepath.Join(vaultPath, relPath))
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+	return strings.Join(lines[:previewLines(len(lines), height)], "\n")
+}
+
+// MatchPreview returns the lines around a search match for the preview
+// window, numbered, with the match line marked and every occurrence of query
+// highlighted. Matches on the file name preview the start of the note.
+func MatchPreview(vaultPath string, match NoteMatch, query string, height int) string {
+	content, err := os.ReadFile(filepath.Join(vaultPath, match.FilePath))
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+
+	// Center the match line in the window.
+	start := 0
+	if match.LineNumber > 0 {
+		start = match.LineNumber - 1 - previewLines(len(lines), height)/2
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + previewLines(len(lines)-start, height)
+
+	var highlight *regexp.Regexp
+	if query != "" {
+		highlight = regexp.MustCompile(`(?i)` + regexp.QuoteMeta(query))
+	}
+	width := len(fmt.Sprint(end))
+	var b strings.Builder
+	for i := start; i < end; i++ {
+		line := lines[i]
+		if highlight != nil {
+			line = highlight.ReplaceAllStringFunc(line, func(s string) string {
+				return previewHighlight + s + previewReset
+			})
+		}
+		marker := " "
+		if i+1 == match.LineNumber {
+			marker = ">"
+		}
+		fmt.Fprintf(&b, "%s%s%*d%s %s\n", marker, previewDim, width, i+1, previewReset, line)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// previewLines is the number of lines of a total that fit in a window of
+// height, which loses two lines to its border.
+func previewLines(total int, height int) int {
+	if height <= 2 {
+		return 0
+	}
+	if total > height-2 {
+		return height - 2
+	}
+	return total
+}
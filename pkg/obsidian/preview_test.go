@@ -0,0 +1,60 @@
+package obsidian_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func previewVault(t *testing.T) string {
+	vaultPath := t.TempDir()
+	var lines []string
+	for i := 1; i <= 20; i++ {
+		lines = append(lines, "line "+strings.Repeat("x", i%3))
+	}
+	lines[9] = "the Apollo launch"
+	assert.NoError(t, os.WriteFile(filepath.Join(vaultPath, "note.md"), []byte(strings.Join(lines, "\n")), 0644))
+	return vaultPath
+}
+
+func TestNotePreview(t *testing.T) {
+	t.Run("Fits the note in the window", func(t *testing.T) {
+		// Arrange
+		vaultPath := previewVault(t)
+		// Act
+		preview := obsidian.NotePreview(vaultPath, "note.md", 5)
+		// Assert
+		assert.Equal(t, "line x\nline xx\nline ", preview)
+	})
+
+	t.Run("Missing note", func(t *testing.T) {
+		assert.Equal(t, "", obsidian.NotePreview(t.TempDir(), "note.md", 5))
+	})
+}
+
+func TestMatchPreview(t *testing.T) {
+	t.Run("Centers and highlights the match", func(t *testing.T) {
+		// Arrange
+		vaultPath := previewVault(t)
+		match := obsidian.NoteMatch{FilePath: "note.md", LineNumber: 10, MatchLine: "the Apollo launch"}
+		// Act
+		preview := obsidian.MatchPreview(vaultPath, match, "apollo", 5)
+		// Assert
+		lines := strings.Split(preview, "\n")
+		assert.Len(t, lines, 3)
+		assert.Contains(t, lines[0], " 9\x1b[0m ")
+		assert.True(t, strings.HasPrefix(lines[1], ">"))
+		assert.Contains(t, lines[1], "the \x1b[1;33mApollo\x1b[0m launch")
+	})
+
+	t.Run("File name match shows the start of the note", func(t *testing.T) {
+		vaultPath := previewVault(t)
+		match := obsidian.NoteMatch{FilePath: "note.md"}
+		preview := obsidian.MatchPreview(vaultPath, match, "note", 4)
+		assert.Equal(t, " \x1b[2m1\x1b[0m line x\n \x1b[2m2\x1b[0m line xx", preview)
+	})
+}
@@ -0,0 +1,301 @@
+package obsidian
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	RecurrenceDay   = "day"
+	RecurrenceWeek  = "week"
+	RecurrenceMonth = "month"
+	RecurrenceYear  = "year"
+)
+
+// Recurrence is a parsed Tasks plugin recurrence rule such as "every 2 weeks
+// on Monday, Friday" or "every month on the last Friday when done".
+type Recurrence struct {
+	Interval int
+	Unit     string
+	Weekdays []time.Weekday
+	// MonthDay is the day of the month for monthly rules, or -1 for the last
+	// day. Zero keeps the day of the reference date.
+	MonthDay int
+	// WeekdayOrdinal selects the nth weekday of the month (1 to 5, or -1 for
+	// the last) for monthly rules, together with Weekdays[0].
+	WeekdayOrdinal int
+	// WhenDone makes the next occurrence relative to the completion date
+	// rather than the task's dates.
+	WhenDone bool
+}
+
+var (
+	recurrenceUnitPattern     = regexp.MustCompile(`^every(?: (\d+))? (day|week|month|year)s?(?: on (.+))?$`)
+	recurrenceWeekdaysPattern = regexp.MustCompile(`^every ((?:[a-z]+)(?:(?:\s*,\s*|\s+and\s+|\s*,\s*and\s+)[a-z]+)*)$`)
+	recurrenceOrdinalPattern  = regexp.MustCompile(`^(?:the )?(\d+)(?:st|nd|rd|th)?$`)
+	recurrenceNthDayPattern   = regexp.MustCompile(`^(?:the )?(\d(?:st|nd|rd|th)|last) ([a-z]+)$`)
+	recurrenceListSeparator   = regexp.MustCompile(`\s*,\s*and\s+|\s*,\s*|\s+and\s+`)
+)
+
+var weekdayNames = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// ParseRecurrence parses the text following 🔁 in a task.
+func ParseRecurrence(rule string) (Recurrence, error) {
+	text := strings.Join(strings.Fields(strings.ToLower(rule)), " ")
+	recurrence := Recurrence{Interval: 1}
+	if strings.HasSuffix(text, " when done") {
+		recurrence.WhenDone = true
+		text = strings.TrimSuffix(text, " when done")
+	}
+
+	if text == "every weekday" {
+		recurrence.Unit = RecurrenceWeek
+		recurrence.Weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+		return recurrence, nil
+	}
+
+	if match := recurrenceUnitPattern.FindStringSubmatch(text); match != nil {
+		if match[1] != "" {
+			interval, err := strconv.Atoi(match[1])
+			if err != nil || interval < 1 {
+				return Recurrence{}, errors.New(InvalidRecurrenceError)
+			}
+			recurrence.Interval = interval
+		}
+		recurrence.Unit = match[2]
+		if match[3] == "" {
+			return recurrence, nil
+		}
+		if err := parseRecurrenceOn(&recurrence, match[3]); err != nil {
+			return Recurrence{}, err
+		}
+		return recurrence, nil
+	}
+
+	if match := recurrenceWeekdaysPattern.FindStringSubmatch(text); match != nil {
+		weekdays, ok := parseWeekdays(match[1])
+		if ok {
+			recurrence.Unit = RecurrenceWeek
+			recurrence.Weekdays = weekdays
+			return recurrence, nil
+		}
+	}
+
+	return Recurrence{}, errors.New(InvalidRecurrenceError)
+}
+
+// parseRecurrenceOn parses the "on ..." part of a rule, which depends on the
+// unit: weekdays for weekly rules, a day or nth weekday for monthly ones.
+func parseRecurrenceOn(recurrence *Recurrence, on string) error {
+	switch recurrence.Unit {
+	case RecurrenceWeek:
+		weekdays, ok := parseWeekdays(on)
+		if !ok {
+			return errors.New(InvalidRecurrenceError)
+		}
+		recurrence.Weekdays = weekdays
+		return nil
+	case RecurrenceMonth:
+		if on == "the last" || on == "the last day" || on == "last day" {
+			recurrence.MonthDay = -1
+			return nil
+		}
+		if match := recurrenceOrdinalPattern.FindStringSubmatch(on); match != nil {
+			day, _ := strconv.Atoi(match[1])
+			if day < 1 || day > 31 {
+				return errors.New(InvalidRecurrenceError)
+			}
+			recurrence.MonthDay = day
+			return nil
+		}
+		if match := recurrenceNthDayPattern.FindStringSubmatch(on); match != nil {
+			weekday, ok := weekdayNames[match[2]]
+			if !ok {
+				return errors.New(InvalidRecurrenceError)
+			}
+			ordinal := -1
+			if match[1] != "last" {
+				ordinal, _ = strconv.Atoi(match[1][:1])
+				if ordinal < 1 || ordinal > 5 {
+					return errors.New(InvalidRecurrenceError)
+				}
+			}
+			recurrence.Weekdays = []time.Weekday{weekday}
+			recurrence.WeekdayOrdinal = ordinal
+			return nil
+		}
+	}
+	return errors.New(InvalidRecurrenceError)
+}
+
+func parseWeekdays(text string) ([]time.Weekday, bool) {
+	var weekdays []time.Weekday
+	for _, name := range recurrenceListSeparator.Split(text, -1) {
+		weekday, ok := weekdayNames[name]
+		if !ok {
+			return nil, false
+		}
+		weekdays = append(weekdays, weekday)
+	}
+	return weekdays, len(weekdays) > 0
+}
+
+// Next returns the first occurrence strictly after ref.
+func (r Recurrence) Next(ref time.Time) time.Time {
+	ref = truncateDay(ref)
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	switch r.Unit {
+	case RecurrenceDay:
+		return ref.AddDate(0, 0, interval)
+	case RecurrenceWeek:
+		if len(r.Weekdays) == 0 {
+			return ref.AddDate(0, 0, 7*interval)
+		}
+		refWeek := startOfWeek(ref)
+		for d := ref.AddDate(0, 0, 1); ; d = d.AddDate(0, 0, 1) {
+			weeks := int(startOfWeek(d).Sub(refWeek).Hours()/24+0.5) / 7
+			if weeks%interval == 0 && containsWeekday(r.Weekdays, d.Weekday()) {
+				return d
+			}
+		}
+	case RecurrenceMonth:
+		if r.MonthDay == 0 && r.WeekdayOrdinal == 0 {
+			return addMonthsClamped(ref, interval)
+		}
+		for months := 0; months <= 12*interval*5; months += interval {
+			first := time.Date(ref.Year(), ref.Month()+time.Month(months), 1, 0, 0, 0, 0, ref.Location())
+			d, ok := r.dayInMonth(first)
+			if ok && d.After(ref) {
+				return d
+			}
+		}
+	case RecurrenceYear:
+		return addMonthsClamped(ref, 12*interval)
+	}
+	return ref
+}
+
+// dayInMonth returns the day of the month starting at first selected by a
+// monthly rule, and false when that month has no such day (e.g. the 31st).
+func (r Recurrence) dayInMonth(first time.Time) (time.Time, bool) {
+	last := first.AddDate(0, 1, -1)
+	if r.WeekdayOrdinal != 0 {
+		weekday := r.Weekdays[0]
+		if r.WeekdayOrdinal == -1 {
+			offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+			return last.AddDate(0, 0, -offset), true
+		}
+		offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+		d := first.AddDate(0, 0, offset+7*(r.WeekdayOrdinal-1))
+		return d, d.Month() == first.Month()
+	}
+	if r.MonthDay == -1 {
+		return last, true
+	}
+	if r.MonthDay > last.Day() {
+		return time.Time{}, false
+	}
+	return first.AddDate(0, 0, r.MonthDay-1), true
+}
+
+// addMonthsClamped adds months to t, keeping the day but clamping it to the
+// end of shorter months, so every month from Jan 31st gives Feb 28th rather
+// than skipping to March.
+func addMonthsClamped(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return truncateDay(t).AddDate(0, 0, -offset)
+}
+
+func containsWeekday(weekdays []time.Weekday, weekday time.Weekday) bool {
+	for _, w := range weekdays {
+		if w == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// NextTaskOccurrence returns the line for the next occurrence of a recurring
+// task completed on doneDate, the way the Tasks plugin creates it. The due
+// date (or else the scheduled or start date) is moved to the next date of the
+// rule and the other dates keep their distance from it. With "when done" the
+// rule counts from doneDate instead. The task must have a recurrence rule.
+func NextTaskOccurrence(task Task, doneDate time.Time) (string, error) {
+	recurrence, err := ParseRecurrence(task.Recurrence)
+	if err != nil {
+		return "", err
+	}
+
+	reference := task.Due
+	for _, date := range []time.Time{task.Scheduled, task.Start} {
+		if reference.IsZero() {
+			reference = date
+		}
+	}
+
+	line := taskDonePattern.ReplaceAllString(task.Line, "")
+	line = taskCancelledPattern.ReplaceAllString(line, "")
+	line = taskBlockIDPattern.ReplaceAllString(line, "")
+	line = setTaskStatus(line, " ")
+	line = replaceTaskDate(taskCreatedPattern, line, func(time.Time) time.Time {
+		return doneDate
+	})
+	if reference.IsZero() {
+		return line, nil
+	}
+
+	base := reference
+	if recurrence.WhenDone {
+		base = doneDate
+	}
+	shift := daysBetween(reference, recurrence.Next(base))
+	for _, pattern := range []*regexp.Regexp{taskDuePattern, taskScheduledPattern, taskStartPattern} {
+		line = replaceTaskDate(pattern, line, func(date time.Time) time.Time {
+			return date.AddDate(0, 0, shift)
+		})
+	}
+	return line, nil
+}
+
+func replaceTaskDate(pattern *regexp.Regexp, line string, update func(time.Time) time.Time) string {
+	return pattern.ReplaceAllStringFunc(line, func(match string) string {
+		value := pattern.FindStringSubmatch(match)[1]
+		date, err := time.ParseInLocation(DateLayout, value, time.Local)
+		if err != nil {
+			return match
+		}
+		return strings.Replace(match, value, update(date).Format(DateLayout), 1)
+	})
+}
+
+func daysBetween(from time.Time, to time.Time) int {
+	from, to = truncateDay(from), truncateDay(to)
+	utcFrom := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	utcTo := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(utcTo.Sub(utcFrom).Hours() / 24)
+}
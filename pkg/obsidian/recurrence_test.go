@@ -0,0 +1,98 @@
+package obsidian_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	t.Run("Parses rules", func(t *testing.T) {
+		tests := map[string]obsidian.Recurrence{
+			"every day":                      {Interval: 1, Unit: obsidian.RecurrenceDay},
+			"every 3 days":                   {Interval: 3, Unit: obsidian.RecurrenceDay},
+			"every week on Monday, Friday":   {Interval: 1, Unit: obsidian.RecurrenceWeek, Weekdays: []time.Weekday{time.Monday, time.Friday}},
+			"every Tuesday and Thursday":     {Interval: 1, Unit: obsidian.RecurrenceWeek, Weekdays: []time.Weekday{time.Tuesday, time.Thursday}},
+			"every month when done":          {Interval: 1, Unit: obsidian.RecurrenceMonth, WhenDone: true},
+			"every 2 months on the 15th":     {Interval: 2, Unit: obsidian.RecurrenceMonth, MonthDay: 15},
+			"every month on the last":        {Interval: 1, Unit: obsidian.RecurrenceMonth, MonthDay: -1},
+			"every month on the 2nd Tuesday": {Interval: 1, Unit: obsidian.RecurrenceMonth, Weekdays: []time.Weekday{time.Tuesday}, WeekdayOrdinal: 2},
+			"every year":                     {Interval: 1, Unit: obsidian.RecurrenceYear},
+		}
+		for rule, want := range tests {
+			got, err := obsidian.ParseRecurrence(rule)
+			assert.NoError(t, err, rule)
+			assert.Equal(t, want, got, rule)
+		}
+	})
+
+	t.Run("Rejects unknown rules", func(t *testing.T) {
+		for _, rule := range []string{"", "daily", "every fortnight", "every week on Funday", "every month on the 40th"} {
+			_, err := obsidian.ParseRecurrence(rule)
+			assert.EqualError(t, err, obsidian.InvalidRecurrenceError, rule)
+		}
+	})
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	// 2024-01-31 is a Wednesday.
+	ref := date(2024, 1, 31)
+	tests := []struct {
+		rule string
+		want time.Time
+	}{
+		{"every 3 days", date(2024, 2, 3)},
+		{"every week", date(2024, 2, 7)},
+		{"every weekday", date(2024, 2, 1)},
+		{"every week on Monday", date(2024, 2, 5)},
+		{"every 2 weeks on Monday, Thursday", date(2024, 2, 1)},
+		{"every 2 weeks on Monday", date(2024, 2, 12)},
+		{"every month", date(2024, 2, 29)},
+		{"every month on the 15th", date(2024, 2, 15)},
+		{"every month on the 31st", date(2024, 3, 31)},
+		{"every month on the last", date(2024, 2, 29)},
+		{"every month on the last Friday", date(2024, 2, 23)},
+		{"every month on the 1st Monday", date(2024, 2, 5)},
+		{"every year", date(2025, 1, 31)},
+	}
+	for _, test := range tests {
+		t.Run(test.rule, func(t *testing.T) {
+			recurrence, err := obsidian.ParseRecurrence(test.rule)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, recurrence.Next(ref))
+		})
+	}
+}
+
+func TestNextTaskOccurrence(t *testing.T) {
+	doneDate := date(2024, 1, 20)
+
+	t.Run("Moves dates relative to the due date", func(t *testing.T) {
+		// Arrange
+		task, _ := obsidian.ParseTask("- [ ] Review 🔁 every week ⏳ 2024-01-08 📅 2024-01-10 ^review")
+		// Act
+		line, err := obsidian.NextTaskOccurrence(task, doneDate)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "- [ ] Review 🔁 every week ⏳ 2024-01-15 📅 2024-01-17", line)
+	})
+
+	t.Run("When done counts from the completion date", func(t *testing.T) {
+		// Arrange
+		task, _ := obsidian.ParseTask("- [ ] Water plants 🔁 every 3 days when done 📅 2024-01-10")
+		// Act
+		line, err := obsidian.NextTaskOccurrence(task, doneDate)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "- [ ] Water plants 🔁 every 3 days when done 📅 2024-01-23", line)
+	})
+
+	t.Run("Task without dates", func(t *testing.T) {
+		task, _ := obsidian.ParseTask("- [ ] Stretch 🔁 every day")
+		line, err := obsidian.NextTaskOccurrence(task, doneDate)
+		assert.NoError(t, err)
+		assert.Equal(t, "- [ ] Stretch 🔁 every day", line)
+	})
+}
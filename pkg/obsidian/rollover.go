@@ -0,0 +1,133 @@
+package obsidian
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// previousDailyNoteSearchDays bounds how far back FindPreviousDailyNote looks.
+const previousDailyNoteSearchDays = 366
+
+// RolloverOptions controls how RolloverTasks carries open tasks over.
+type RolloverOptions struct {
+	Heading      string
+	Move         bool
+	MarkMigrated bool
+}
+
+// FindPreviousDailyNote returns the vault relative path of the most recent
+// daily note before date, looking back up to a year.
+func FindPreviousDailyNote(vaultPath string, dailyNotesConfig DailyNotesConfig, date time.Time) (string, error) {
+	for days := 1; days <= previousDailyNoteSearchDays; days++ {
+		notePath := DailyNotePath(dailyNotesConfig, date.AddDate(0, 0, -days))
+		if info, err := os.Stat(filepath.Join(vaultPath, notePath)); err == nil && !info.IsDir() {
+			return notePath, nil
+		}
+	}
+	return "", errors.New(PreviousDailyNoteNotFoundError)
+}
+
+// RolloverTasks copies the open tasks of the note at fromPath under the
+// heading of the note at toPath, both vault relative. Nested open tasks keep
+// their indent relative to an open parent, and tasks already in the target
+// note are skipped. With Move the tasks are removed from the source note,
+// with MarkMigrated they are marked [>] there. It returns the number of tasks
+// added to the target note.
+func RolloverTasks(vaultPath string, fromPath string, toPath string, options RolloverOptions) (int, error) {
+	sourcePath := filepath.Join(vaultPath, fromPath)
+	sourceContent, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return 0, errors.New(VaultReadError)
+	}
+	targetPath := filepath.Join(vaultPath, toPath)
+	targetContent, err := os.ReadFile(targetPath)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, errors.New(VaultReadError)
+	}
+
+	existing := map[string]bool{}
+	for _, task := range ParseTasks(toPath, string(targetContent)) {
+		existing[task.Description] = true
+	}
+
+	type parent struct {
+		indent    string
+		outIndent string
+		open      bool
+	}
+	var parents []parent
+	var lines []string
+	rolled := map[int]bool{}
+	for _, task := range ParseTasks(fromPath, string(sourceContent)) {
+		indent := leadingWhitespace(task.Line)
+		for len(parents) > 0 && len(parents[len(parents)-1].indent) >= len(indent) {
+			parents = parents[:len(parents)-1]
+		}
+		outIndent := ""
+		if len(parents) > 0 && parents[len(parents)-1].open && strings.HasPrefix(indent, parents[len(parents)-1].indent) {
+			top := parents[len(parents)-1]
+			outIndent = top.outIndent + indent[len(top.indent):]
+		}
+		parents = append(parents, parent{indent: indent, outIndent: outIndent, open: task.IsOpen()})
+
+		if !task.IsOpen() {
+			continue
+		}
+		rolled[task.LineNumber] = true
+		if existing[task.Description] {
+			continue
+		}
+		lines = append(lines, outIndent+strings.TrimLeft(task.Line, " \t"))
+	}
+
+	if len(lines) > 0 {
+		err = InsertIntoNote(targetPath, options.Heading, strings.Join(lines, "\n"), false)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if len(rolled) > 0 && (options.Move || options.MarkMigrated) {
+		err = updateRolledOverTasks(sourcePath, string(sourceContent), rolled, options.Move)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(lines), nil
+}
+
+func updateRolledOverTasks(notePath string, content string, rolled map[int]bool, move bool) error {
+	info, err := os.Stat(notePath)
+	if err != nil {
+		return errors.New(VaultReadError)
+	}
+
+	var updated []string
+	for i, line := range strings.Split(content, "\n") {
+		if !rolled[i+1] {
+			updated = append(updated, line)
+			continue
+		}
+		if move {
+			continue
+		}
+		lineEnding := ""
+		if strings.HasSuffix(line, "\r") {
+			lineEnding = "\r"
+		}
+		updated = append(updated, setTaskStatus(strings.TrimRight(line, "\r"), ">")+lineEnding)
+	}
+
+	err = os.WriteFile(notePath, []byte(strings.Join(updated, "\n")), info.Mode())
+	if err != nil {
+		return errors.New(VaultWriteError)
+	}
+	return nil
+}
+
+func leadingWhitespace(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
@@ -0,0 +1,72 @@
+package obsidian_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindPreviousDailyNote(t *testing.T) {
+	dailyNotesConfig := obsidian.DailyNotesConfig{Folder: "Daily", Format: "YYYY-MM-DD"}
+
+	t.Run("Skips days without a note", func(t *testing.T) {
+		// Arrange
+		vaultPath := mocks.CreateVaultFiles(t, map[string]string{
+			"Daily/2024-03-01.md": "",
+			"Daily/2024-03-05.md": "",
+		})
+		// Act
+		notePath, err := obsidian.FindPreviousDailyNote(vaultPath, dailyNotesConfig, time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC))
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Daily/2024-03-01.md", notePath)
+	})
+
+	t.Run("No previous note", func(t *testing.T) {
+		_, err := obsidian.FindPreviousDailyNote(t.TempDir(), dailyNotesConfig, time.Now())
+		assert.EqualError(t, err, obsidian.PreviousDailyNoteNotFoundError)
+	})
+}
+
+func TestRolloverTasks(t *testing.T) {
+	source := "## Tasks\n- [ ] open\n- [x] done\n  - [ ] orphan\n- [ ] parent\n  - [ ] child\n  - [x] finished child\n- [>] already migrated\n"
+
+	t.Run("Copies open tasks under heading", func(t *testing.T) {
+		// Arrange
+		vaultPath := mocks.CreateVaultFiles(t, map[string]string{
+			"old.md": source,
+			"new.md": "# Today\n\n## Tasks\n- [ ] open\n\n## Notes\n",
+		})
+		// Act
+		count, err := obsidian.RolloverTasks(vaultPath, "old.md", "new.md", obsidian.RolloverOptions{Heading: "## Tasks"})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, count)
+		assert.Equal(t, "# Today\n\n## Tasks\n- [ ] open\n- [ ] orphan\n- [ ] parent\n  - [ ] child\n\n## Notes\n", readVaultFile(t, vaultPath, "new.md"))
+		assert.Equal(t, source, readVaultFile(t, vaultPath, "old.md"))
+	})
+
+	t.Run("Marks tasks migrated in the source", func(t *testing.T) {
+		// Arrange
+		vaultPath := mocks.CreateVaultFiles(t, map[string]string{"old.md": "- [ ] a\r\n- [x] b\r\n"})
+		// Act
+		_, err := obsidian.RolloverTasks(vaultPath, "old.md", "new.md", obsidian.RolloverOptions{MarkMigrated: true})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "- [>] a\r\n- [x] b\r\n", readVaultFile(t, vaultPath, "old.md"))
+		assert.Equal(t, "- [ ] a\n", readVaultFile(t, vaultPath, "new.md"))
+	})
+
+	t.Run("Moves tasks out of the source", func(t *testing.T) {
+		// Arrange
+		vaultPath := mocks.CreateVaultFiles(t, map[string]string{"old.md": "- [ ] a\n- [x] b\n"})
+		// Act
+		_, err := obsidian.RolloverTasks(vaultPath, "old.md", "new.md", obsidian.RolloverOptions{Move: true})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "- [x] b\n", readVaultFile(t, vaultPath, "old.md"))
+	})
+}
@@ -0,0 +1,289 @@
+package obsidian
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Heading is a Markdown ATX heading found in a note.
+type Heading struct {
+	Level      int
+	Text       string
+	LineNumber int
+}
+
+var headingPattern = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+
+// ParseHeadings returns the headings of a note in order, ignoring the
+// frontmatter and anything inside fenced code blocks.
+func ParseHeadings(content string) []Heading {
+	var headings []Heading
+	lines := strings.Split(content, "\n")
+	fence := ""
+	for i := frontmatterEnd(lines); i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r")
+		if marker := codeFenceMarker(line); marker != "" {
+			if fence == "" {
+				fence = marker
+			} else if strings.HasPrefix(marker, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+
+		match := headingPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		headings = append(headings, Heading{
+			Level:      len(match[1]),
+			Text:       strings.TrimSpace(match[2]),
+			LineNumber: i + 1,
+		})
+	}
+	return headings
+}
+
+// FindHeading looks up a heading by its text, case-insensitively. The query
+// may include the leading hashes (e.g. "## Log") to also match the level.
+func FindHeading(headings []Heading, query string) (Heading, bool) {
+	level, text := splitHeadingQuery(query)
+	for _, heading := range headings {
+		if level != 0 && heading.Level != level {
+			continue
+		}
+		if strings.EqualFold(heading.Text, text) {
+			return heading, true
+		}
+	}
+	return Heading{}, false
+}
+
+// SectionEnd returns the line number just past the section started by
+// heading: the next heading of the same or a higher level, or the end of the
+// note.
+func SectionEnd(headings []Heading, heading Heading, lineCount int) int {
+	for _, next := range headings {
+		if next.LineNumber > heading.LineNumber && next.Level <= heading.Level {
+			return next.LineNumber
+		}
+	}
+	return lineCount + 1
+}
+
+// SplitSubpath splits a link target such as "Note#Heading" into the note
+// name and the part after the first #, which is empty when there is none.
+func SplitSubpath(target string) (string, string) {
+	note, subpath, _ := strings.Cut(target, "#")
+	return note, subpath
+}
+
+// ExtractSection returns the section of a note under heading, from the
+// heading line up to the next heading of the same or a higher level. Nested
+// headings can be given the way Obsidian links to them, e.g. "Setup#Linux".
+func ExtractSection(content string, heading string) (string, error) {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	headings := ParseHeadings(content)
+
+	chain, ok := resolveSubpath(headings, strings.Split(heading, "#"), len(lines))
+	if !ok {
+		return "", errors.New(SectionNotFoundError)
+	}
+	found := chain[len(chain)-1]
+	start, end := found.LineNumber, SectionEnd(headings, found, len(lines))
+
+	section := lines[start-1 : end-1]
+	for len(section) > 1 && strings.TrimSpace(section[len(section)-1]) == "" {
+		section = section[:len(section)-1]
+	}
+	return strings.Join(section, "\n") + "\n", nil
+}
+
+// resolveSubpath finds the heading each part of a nested subpath such as
+// "Setup#Linux" names, the way Obsidian resolves links: each part is the
+// first heading with that text inside the section of the part before it.
+func resolveSubpath(headings []Heading, parts []string, lineCount int) ([]Heading, bool) {
+	var chain []Heading
+	var found Heading
+	start, end := 0, lineCount+1
+	for _, query := range parts {
+		var candidates []Heading
+		for _, candidate := range headings {
+			if candidate.LineNumber > start && candidate.LineNumber < end && candidate.Level > found.Level {
+				candidates = append(candidates, candidate)
+			}
+		}
+		var ok bool
+		found, ok = FindHeading(candidates, query)
+		if !ok {
+			return nil, false
+		}
+		chain = append(chain, found)
+		start, end = found.LineNumber, SectionEnd(headings, found, lineCount)
+	}
+	return chain, true
+}
+
+// RenameHeading changes the text of a heading to newText, keeping its
+// level. A heading that is not the first with its text can be given the way
+// Obsidian links to it, e.g. "Day 2#Notes". It returns the updated content
+// and the heading as it was.
+func RenameHeading(content string, oldHeading string, newText string) (string, Heading, error) {
+	newText = strings.TrimSpace(newText)
+	if newText == "" || strings.Contains(newText, "\n") {
+		return "", Heading{}, errors.New(InvalidHeadingError)
+	}
+	headings := ParseHeadings(content)
+	lineCount := len(strings.Split(strings.TrimSuffix(content, "\n"), "\n"))
+	var found Heading
+	chain, ok := resolveSubpath(headings, strings.Split(oldHeading, "#"), lineCount)
+	if ok {
+		found = chain[len(chain)-1]
+	} else {
+		// Such as "## Notes", or a heading with a # in its text.
+		found, ok = FindHeading(headings, oldHeading)
+	}
+	if !ok {
+		return "", Heading{}, errors.New(SectionNotFoundError)
+	}
+
+	lines := strings.Split(content, "\n")
+	line := lines[found.LineNumber-1]
+	lineEnding := ""
+	if strings.HasSuffix(line, "\r") {
+		lineEnding = "\r"
+	}
+	indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+	lines[found.LineNumber-1] = indent + strings.Repeat("#", found.Level) + " " + newText + lineEnding
+	return strings.Join(lines, "\n"), found, nil
+}
+
+// InsertUnderHeading inserts text at the end of the section under heading,
+// or right below the heading when atTop is set. A missing heading is created
+// at the end of the note. With an empty heading the whole note is treated as
+// the section, so text goes to the end of the note or the top of its body.
+func InsertUnderHeading(content string, heading string, text string, atTop bool) string {
+	text = strings.TrimRight(text, "\r\n")
+	hadTrailingNewline := content == "" || strings.HasSuffix(content, "\n")
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	if content == "" {
+		lines = nil
+	}
+
+	start, end := frontmatterEnd(lines), len(lines)
+	if heading != "" {
+		headings := ParseHeadings(content)
+		found, ok := FindHeading(headings, heading)
+		if !ok {
+			return appendHeading(lines, heading, text)
+		}
+		start, end = found.LineNumber, SectionEnd(headings, found, len(lines))-1
+	}
+
+	insertAt := start
+	if atTop {
+		for insertAt < end && strings.TrimSpace(lines[insertAt]) == "" {
+			insertAt++
+		}
+	} else {
+		insertAt = end
+		for insertAt > start && strings.TrimSpace(lines[insertAt-1]) == "" {
+			insertAt--
+		}
+	}
+
+	result := make([]string, 0, len(lines)+1)
+	result = append(result, lines[:insertAt]...)
+	result = append(result, text)
+	result = append(result, lines[insertAt:]...)
+	updated := strings.Join(result, "\n")
+	if hadTrailingNewline {
+		updated += "\n"
+	}
+	return updated
+}
+
+// InsertIntoNote inserts text into the note file at notePath using
+// InsertUnderHeading, creating the note if it does not exist yet.
+func InsertIntoNote(notePath string, heading string, text string, atTop bool) error {
+	mode := os.FileMode(0644)
+	content, err := os.ReadFile(notePath)
+	if err == nil {
+		if info, err := os.Stat(notePath); err == nil {
+			mode = info.Mode()
+		}
+	} else if !os.IsNotExist(err) {
+		return errors.New(VaultReadError)
+	}
+
+	updated := InsertUnderHeading(string(content), heading, text, atTop)
+
+	err = os.MkdirAll(filepath.Dir(notePath), 0755)
+	if err != nil {
+		return errors.New(VaultWriteError)
+	}
+	err = os.WriteFile(notePath, []byte(updated), mode)
+	if err != nil {
+		return errors.New(VaultWriteError)
+	}
+	return nil
+}
+
+func appendHeading(lines []string, heading string, text string) string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) > 0 {
+		lines = append(lines, "")
+	}
+
+	level, headingText := splitHeadingQuery(heading)
+	if level == 0 {
+		level = 2
+	}
+	lines = append(lines, strings.Repeat("#", level)+" "+headingText, text)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func splitHeadingQuery(query string) (int, string) {
+	query = strings.TrimSpace(query)
+	level := len(query) - len(strings.TrimLeft(query, "#"))
+	if level > 6 || (level > 0 && len(query) > level && query[level] != ' ') {
+		return 0, query
+	}
+	return level, strings.TrimSpace(query[level:])
+}
+
+// frontmatterEnd returns the index of the first line after the YAML
+// frontmatter, or 0 if the note has none.
+func frontmatterEnd(lines []string) int {
+	if len(lines) == 0 || strings.TrimRight(lines[0], "\r") != "---" {
+		return 0
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], "\r") == "---" {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func codeFenceMarker(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return ""
+	}
+	for _, fence := range []string{"```", "~~~"} {
+		if strings.HasPrefix(trimmed, fence) {
+			char := fence[0:1]
+			return strings.Repeat(char, len(trimmed)-len(strings.TrimLeft(trimmed, char)))
+		}
+	}
+	return ""
+}
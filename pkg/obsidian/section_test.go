@@ -0,0 +1,180 @@
+package obsidian_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHeadings(t *testing.T) {
+	t.Run("Parses headings and skips frontmatter and code", func(t *testing.T) {
+		// Arrange
+		content := "---\ntitle: # not a heading\n---\n# Title\ntext\n## Log ##\n```\n# comment\n```\n###No space\n### Sub"
+		// Act
+		headings := obsidian.ParseHeadings(content)
+		// Assert
+		assert.Equal(t, []obsidian.Heading{
+			{Level: 1, Text: "Title", LineNumber: 4},
+			{Level: 2, Text: "Log", LineNumber: 6},
+			{Level: 3, Text: "Sub", LineNumber: 11},
+		}, headings)
+	})
+}
+
+func TestFindHeading(t *testing.T) {
+	headings := []obsidian.Heading{
+		{Level: 1, Text: "Log", LineNumber: 1},
+		{Level: 2, Text: "Log", LineNumber: 3},
+	}
+
+	t.Run("Matches text in any level", func(t *testing.T) {
+		heading, ok := obsidian.FindHeading(headings, "log")
+		assert.True(t, ok)
+		assert.Equal(t, 1, heading.LineNumber)
+	})
+
+	t.Run("Matches level when given", func(t *testing.T) {
+		heading, ok := obsidian.FindHeading(headings, "## Log")
+		assert.True(t, ok)
+		assert.Equal(t, 3, heading.LineNumber)
+	})
+
+	t.Run("Missing heading", func(t *testing.T) {
+		_, ok := obsidian.FindHeading(headings, "### Log")
+		assert.False(t, ok)
+	})
+}
+
+func TestExtractSection(t *testing.T) {
+	content := "# Title\nintro\n## Setup\nsteps\n### Linux\napt\n\n## Usage\nrun\n"
+
+	t.Run("Returns the section up to the next heading of the same level", func(t *testing.T) {
+		section, err := obsidian.ExtractSection(content, "Setup")
+		assert.NoError(t, err)
+		assert.Equal(t, "## Setup\nsteps\n### Linux\napt\n", section)
+	})
+
+	t.Run("Last section runs to the end of the note", func(t *testing.T) {
+		section, err := obsidian.ExtractSection(content, "usage")
+		assert.NoError(t, err)
+		assert.Equal(t, "## Usage\nrun\n", section)
+	})
+
+	t.Run("Nested headings", func(t *testing.T) {
+		section, err := obsidian.ExtractSection(content, "Setup#Linux")
+		assert.NoError(t, err)
+		assert.Equal(t, "### Linux\napt\n", section)
+	})
+
+	t.Run("Missing heading", func(t *testing.T) {
+		_, err := obsidian.ExtractSection(content, "Usage#Linux")
+		assert.EqualError(t, err, obsidian.SectionNotFoundError)
+	})
+}
+
+func TestRenameHeading(t *testing.T) {
+	t.Run("Keeps the level and line ending", func(t *testing.T) {
+		// Act
+		updated, heading, err := obsidian.RenameHeading("# Title\r\n## Old ##\r\ntext\r\n", "old", "New")
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "# Title\r\n## New\r\ntext\r\n", updated)
+		assert.Equal(t, "Old", heading.Text)
+	})
+
+	t.Run("Nested heading with the same text as an earlier one", func(t *testing.T) {
+		// Act
+		updated, heading, err := obsidian.RenameHeading("# Day 1\n## Notes\n# Day 2\n## Notes\n", "Day 2#notes", "Summary")
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "# Day 1\n## Notes\n# Day 2\n## Summary\n", updated)
+		assert.Equal(t, 4, heading.LineNumber)
+	})
+
+	t.Run("Heading with its level", func(t *testing.T) {
+		// Act
+		updated, _, err := obsidian.RenameHeading("# Notes\n## Notes\n", "## Notes", "Log")
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "# Notes\n## Log\n", updated)
+	})
+
+	t.Run("Heading with a # in its text", func(t *testing.T) {
+		// Act
+		updated, _, err := obsidian.RenameHeading("## C# tips\n", "C# tips", "Go tips")
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "## Go tips\n", updated)
+	})
+
+	t.Run("Missing heading", func(t *testing.T) {
+		_, _, err := obsidian.RenameHeading("# Title\n", "Old", "New")
+		assert.EqualError(t, err, obsidian.SectionNotFoundError)
+	})
+
+	t.Run("Empty new heading", func(t *testing.T) {
+		_, _, err := obsidian.RenameHeading("# Old\n", "Old", " ")
+		assert.EqualError(t, err, obsidian.InvalidHeadingError)
+	})
+}
+
+func TestInsertUnderHeading(t *testing.T) {
+	note := "# Day\n\n## Log\n\n- first\n\n## Notes\ntext\n"
+	tests := []struct {
+		testName string
+		content  string
+		heading  string
+		atTop    bool
+		want     string
+	}{
+		{"End of section", note, "## Log", false, "# Day\n\n## Log\n\n- first\n- new\n\n## Notes\ntext\n"},
+		{"Top of section", note, "Log", true, "# Day\n\n## Log\n\n- new\n- first\n\n## Notes\ntext\n"},
+		{"Section containing sub headings", "## Log\n- a\n### Sub\n- b\n## Next\n", "## Log", false, "## Log\n- a\n### Sub\n- b\n- new\n## Next\n"},
+		{"Last section", note, "## Notes", false, "# Day\n\n## Log\n\n- first\n\n## Notes\ntext\n- new\n"},
+		{"Empty section", "## Log\n## Notes\n", "## Log", false, "## Log\n- new\n## Notes\n"},
+		{"Missing heading is created", "# Day\n\ntext\n\n", "## Log", false, "# Day\n\ntext\n\n## Log\n- new\n"},
+		{"Missing heading without level", "", "Log", false, "## Log\n- new\n"},
+		{"No heading appends to the end", "text", "", false, "text\n- new"},
+		{"No heading at top keeps frontmatter first", "---\na: b\n---\ntext\n", "", true, "---\na: b\n---\n- new\ntext\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			// Act
+			got := obsidian.InsertUnderHeading(test.content, test.heading, "- new", test.atTop)
+			// Assert
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestInsertIntoNote(t *testing.T) {
+	t.Run("Creates missing note", func(t *testing.T) {
+		// Arrange
+		notePath := filepath.Join(t.TempDir(), "folder", "note.md")
+		// Act
+		err := obsidian.InsertIntoNote(notePath, "## Log", "entry", false)
+		// Assert
+		assert.NoError(t, err)
+		content, err := os.ReadFile(notePath)
+		assert.NoError(t, err)
+		assert.Equal(t, "## Log\nentry\n", string(content))
+	})
+
+	t.Run("Updates existing note", func(t *testing.T) {
+		// Arrange
+		notePath := filepath.Join(t.TempDir(), "note.md")
+		err := os.WriteFile(notePath, []byte("## Log\n- a\n"), 0644)
+		assert.NoError(t, err)
+		// Act
+		err = obsidian.InsertIntoNote(notePath, "## Log", "- b", false)
+		// Assert
+		assert.NoError(t, err)
+		content, err := os.ReadFile(notePath)
+		assert.NoError(t, err)
+		assert.Equal(t, "## Log\n- a\n- b\n", string(content))
+	})
+}
@@ -0,0 +1,482 @@
+package obsidian
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// TagCount is a tag and the number of times it is used in the vault.
+type TagCount struct {
+	Name  string
+	Count int
+}
+
+// Obsidian only treats # as a tag when it starts a line or follows
+// whitespace, which keeps headings, links to headings and URL fragments out.
+var inlineTagPattern = regexp.MustCompile(`(^|\s)#([\p{L}\p{N}_/\-]+)`)
+
+var (
+	frontmatterTagsPattern = regexp.MustCompile(`(?i)^(tags?)[ \t]*:[ \t]*(.*)$`)
+	frontmatterItemPattern = regexp.MustCompile(`^([ \t]*-[ \t]*)(.*?)[ \t]*$`)
+)
+
+// InlineTags returns the #tags in a line of text, without the leading #.
+// Purely numeric tags such as #123 are not tags in Obsidian and are skipped.
+func InlineTags(text string) []string {
+	var tags []string
+	for _, match := range inlineTagPattern.FindAllStringSubmatch(text, -1) {
+		tag := strings.Trim(match[2], "/")
+		if tag == "" || isNumeric(tag) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeTag strips the leading # and surrounding slashes from a tag.
+func NormalizeTag(tag string) string {
+	return strings.Trim(strings.TrimPrefix(strings.TrimSpace(tag), "#"), "/")
+}
+
+// NoteTags returns every tag used in a note: the frontmatter tags followed by
+// the inline tags of the body, leaving out code blocks and inline code.
+func NoteTags(content string) []string {
+	var tags []string
+	if properties, _, err := ParseFrontmatter(content); err == nil {
+		for _, key := range []string{"tags", "tag"} {
+			for _, tag := range FrontmatterList(properties, key) {
+				for _, field := range strings.Fields(tag) {
+					if field = NormalizeTag(field); field != "" {
+						tags = append(tags, field)
+					}
+				}
+			}
+		}
+	}
+	mapProse(content, func(text string) string {
+		tags = append(tags, InlineTags(text)...)
+		return text
+	})
+	return tags
+}
+
+// CountTags counts how often each tag is used across the vault. Tags differing
+// only in case are counted together under the first spelling found. The
+// result is sorted by tag name.
+func CountTags(vaultPath string) ([]TagCount, error) {
+	counts := map[string]*TagCount{}
+	err := WalkNotes(vaultPath, func(relPath string, content []byte) error {
+		for _, tag := range NoteTags(string(content)) {
+			key := strings.ToLower(tag)
+			if counts[key] == nil {
+				counts[key] = &TagCount{Name: tag}
+			}
+			counts[key].Count++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]TagCount, 0, len(counts))
+	for _, count := range counts {
+		result = append(result, *count)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return strings.ToLower(result[i].Name) < strings.ToLower(result[j].Name)
+	})
+	return result, nil
+}
+
+// TagNode is a tag in the tag hierarchy, where proj/alpha is nested under
+// proj. Count is the number of uses of the tag itself and Total includes the
+// tags nested under it.
+type TagNode struct {
+	Name     string
+	Path     string
+	Count    int
+	Total    int
+	Children []*TagNode
+}
+
+// TagTree arranges tag counts into the nested hierarchy Obsidian shows in its
+// tags pane. Parents that are only used through their children get a count
+// of zero.
+func TagTree(counts []TagCount) []*TagNode {
+	var roots []*TagNode
+	nodes := map[string]*TagNode{}
+	for _, count := range counts {
+		var parent *TagNode
+		parts := strings.Split(count.Name, "/")
+		for i, part := range parts {
+			path := strings.Join(parts[:i+1], "/")
+			node := nodes[strings.ToLower(path)]
+			if node == nil {
+				node = &TagNode{Name: part, Path: path}
+				nodes[strings.ToLower(path)] = node
+				if parent == nil {
+					roots = append(roots, node)
+				} else {
+					parent.Children = append(parent.Children, node)
+				}
+			}
+			node.Total += count.Count
+			parent = node
+		}
+		parent.Count += count.Count
+	}
+	return roots
+}
+
+// RenameTags renames the tags in from, and the tags nested under them, to
+// to in every note of the vault, both inline and in frontmatter. Merging
+// several tags into one removes the duplicates this creates in frontmatter
+// lists. It returns the vault relative paths of the notes changed.
+func RenameTags(vaultPath string, from []string, to string) ([]string, error) {
+	to = NormalizeTag(to)
+	if to == "" || strings.ContainsAny(to, " \t#") {
+		return nil, errors.New(InvalidTagError)
+	}
+	var sources []string
+	for _, tag := range from {
+		if tag = NormalizeTag(tag); tag == "" {
+			return nil, errors.New(InvalidTagError)
+		}
+		sources = append(sources, tag)
+	}
+
+	var changed []string
+	err := WalkNotes(vaultPath, func(relPath string, content []byte) error {
+		updated := RenameTagsInNote(string(content), sources, to)
+		if updated == string(content) {
+			return nil
+		}
+		notePath := filepath.Join(vaultPath, relPath)
+		info, err := os.Stat(notePath)
+		if err != nil {
+			return errors.New(VaultReadError)
+		}
+		err = os.WriteFile(notePath, []byte(updated), info.Mode())
+		if err != nil {
+			return errors.New(VaultWriteError)
+		}
+		changed = append(changed, relPath)
+		return nil
+	})
+	return changed, err
+}
+
+// RenameTagsInNote rewrites the tags in from, and the tags nested under
+// them, to to in the content of a note. Code is left untouched and the
+// formatting of the frontmatter is kept.
+func RenameTagsInNote(content string, from []string, to string) string {
+	rename := func(tag string) (string, bool) {
+		for _, source := range from {
+			if strings.EqualFold(tag, source) {
+				return to, true
+			}
+			if len(tag) > len(source) && strings.EqualFold(tag[:len(source)+1], source+"/") {
+				return to + tag[len(source):], true
+			}
+		}
+		return tag, false
+	}
+
+	content = mapProse(content, func(text string) string {
+		return inlineTagPattern.ReplaceAllStringFunc(text, func(match string) string {
+			parts := inlineTagPattern.FindStringSubmatch(match)
+			renamed, ok := rename(parts[2])
+			if !ok {
+				return match
+			}
+			return parts[1] + "#" + renamed
+		})
+	})
+	return renameFrontmatterTags(content, rename)
+}
+
+// renameFrontmatterTags rewrites the tags property, which may be a block
+// list, a flow list ([a, b]) or a comma separated string.
+func renameFrontmatterTags(content string, rename func(string) (string, bool)) string {
+	lines := strings.Split(content, "\n")
+	end := frontmatterEnd(lines)
+	for i := 1; i < end-1; i++ {
+		line := strings.TrimRight(lines[i], "\r")
+		match := frontmatterTagsPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		lineEnding := lines[i][len(line):]
+
+		if value := match[2]; value != "" {
+			renamed := renameTagList(value, rename)
+			if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+				renamed = "[" + renameTagList(value[1:len(value)-1], rename) + "]"
+			}
+			if renamed != value {
+				lines[i] = match[1] + ": " + renamed + lineEnding
+			}
+			continue
+		}
+
+		// A block list, which may be indented or not.
+		seen := map[string]bool{}
+		var kept []string
+		j := i + 1
+		for ; j < end-1; j++ {
+			item := strings.TrimRight(lines[j], "\r")
+			itemMatch := frontmatterItemPattern.FindStringSubmatch(item)
+			if itemMatch == nil {
+				break
+			}
+			renamed := renameTagItem(itemMatch[2], rename)
+			if seen[strings.ToLower(NormalizeTag(unquote(renamed)))] {
+				continue
+			}
+			seen[strings.ToLower(NormalizeTag(unquote(renamed)))] = true
+			kept = append(kept, itemMatch[1]+renamed+lines[j][len(item):])
+		}
+		lines = append(lines[:i+1], append(kept, lines[j:]...)...)
+		end -= j - (i + 1) - len(kept)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renameTagList renames the items of a comma separated list, keeping the
+// quotes and # of each item, and drops duplicates.
+func renameTagList(list string, rename func(string) (string, bool)) string {
+	seen := map[string]bool{}
+	var items []string
+	changed := false
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		renamed := renameTagItem(item, rename)
+		if unquote(item) == item && strings.ContainsAny(item, " \t") {
+			// Plain strings may also separate tags with spaces.
+			fields := strings.Fields(item)
+			for i, field := range fields {
+				fields[i] = renameTagItem(field, rename)
+			}
+			renamed = strings.Join(fields, " ")
+		}
+		key := strings.ToLower(NormalizeTag(unquote(renamed)))
+		if renamed != item {
+			changed = true
+		}
+		if seen[key] && key != "" {
+			changed = true
+			continue
+		}
+		seen[key] = true
+		items = append(items, renamed)
+	}
+	if !changed {
+		return list
+	}
+	return strings.Join(items, ", ")
+}
+
+func renameTagItem(item string, rename func(string) (string, bool)) string {
+	quote := ""
+	if len(item) >= 2 && (item[0] == '"' || item[0] == '\'') && item[len(item)-1] == item[0] {
+		quote = item[:1]
+	}
+	tag := unquote(item)
+	hash := ""
+	if strings.HasPrefix(tag, "#") {
+		hash, tag = "#", tag[1:]
+	}
+	renamed, ok := rename(tag)
+	if !ok {
+		return item
+	}
+	return quote + hash + renamed + quote
+}
+
+func unquote(item string) string {
+	if len(item) >= 2 && (item[0] == '"' || item[0] == '\'') && item[len(item)-1] == item[0] {
+		return item[1 : len(item)-1]
+	}
+	return item
+}
+
+// AddTags adds tags to the frontmatter of the notes with the given vault
+// relative paths. Notes that already have every tag are left alone. It
+// returns the paths of the notes changed.
+func AddTags(vaultPath string, notePaths []string, tags []string) ([]string, error) {
+	var normalized []string
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if tag == "" || strings.ContainsAny(tag, " \t#") {
+			return nil, errors.New(InvalidTagError)
+		}
+		normalized = append(normalized, tag)
+	}
+
+	var changed []string
+	for _, notePath := range notePaths {
+		fullPath := filepath.Join(vaultPath, notePath)
+		info, err := os.Stat(fullPath)
+		if err != nil {
+			return changed, errors.New(NoteDoesNotExistError)
+		}
+		content, err := os.ReadFile(fullPath)
+		if err != nil {
+			return changed, errors.New(VaultReadError)
+		}
+		updated := AddTagsToNote(string(content), normalized)
+		if updated == string(content) {
+			continue
+		}
+		err = os.WriteFile(fullPath, []byte(updated), info.Mode())
+		if err != nil {
+			return changed, errors.New(VaultWriteError)
+		}
+		changed = append(changed, notePath)
+	}
+	return changed, nil
+}
+
+// AddTagsToNote adds the tags a note does not have yet to its frontmatter
+// tags, in the style the tags are already written in. A note without
+// frontmatter or tags gets a block list.
+func AddTagsToNote(content string, tags []string) string {
+	have := map[string]bool{}
+	for _, tag := range NoteTags(content) {
+		have[strings.ToLower(tag)] = true
+	}
+	var missing []string
+	for _, tag := range tags {
+		if !have[strings.ToLower(tag)] {
+			have[strings.ToLower(tag)] = true
+			missing = append(missing, tag)
+		}
+	}
+	if len(missing) == 0 {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	end := frontmatterEnd(lines)
+	lineEnding := ""
+	if len(lines) > 0 && strings.HasSuffix(lines[0], "\r") {
+		lineEnding = "\r"
+	}
+	blockItems := func(indent string) []string {
+		items := make([]string, len(missing))
+		for i, tag := range missing {
+			items[i] = indent + "- " + tag + lineEnding
+		}
+		return items
+	}
+
+	if end == 0 {
+		frontmatter := append([]string{"---" + lineEnding, "tags:" + lineEnding}, blockItems("  ")...)
+		return strings.Join(append(frontmatter, append([]string{"---" + lineEnding}, lines...)...), "\n")
+	}
+
+	for i := 1; i < end-1; i++ {
+		line := strings.TrimRight(lines[i], "\r")
+		match := frontmatterTagsPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		ending := lines[i][len(line):]
+
+		value := match[2]
+		switch {
+		case strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]"):
+			items := strings.TrimSpace(value[1 : len(value)-1])
+			if items != "" {
+				items += ", "
+			}
+			lines[i] = match[1] + ": [" + items + strings.Join(missing, ", ") + "]" + ending
+		case value != "":
+			separator := " "
+			if strings.Contains(value, ",") {
+				separator = ", "
+			}
+			lines[i] = match[1] + ": " + value + separator + strings.Join(missing, separator) + ending
+		default:
+			// Add to the end of the block list, indented like its items.
+			j, indent := i+1, "  "
+			for ; j < end-1; j++ {
+				itemMatch := frontmatterItemPattern.FindStringSubmatch(strings.TrimRight(lines[j], "\r"))
+				if itemMatch == nil {
+					break
+				}
+				indent = itemMatch[1][:len(itemMatch[1])-len(strings.TrimLeft(itemMatch[1], " \t"))]
+			}
+			lines = append(lines[:j], append(blockItems(indent), lines[j:]...)...)
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	// The frontmatter has no tags yet.
+	properties := append([]string{"tags:" + lineEnding}, blockItems("  ")...)
+	lines = append(lines[:end-1], append(properties, lines[end-1:]...)...)
+	return strings.Join(lines, "\n")
+}
+
+// mapProse calls fn on the prose of a note, that is everything outside the
+// frontmatter, fenced code blocks and inline code, and returns the note with
+// each piece replaced by what fn returns.
+func mapProse(content string, fn func(text string) string) string {
+	lines := strings.Split(content, "\n")
+	fence := ""
+	for i := frontmatterEnd(lines); i < len(lines); i++ {
+		if marker := codeFenceMarker(strings.TrimRight(lines[i], "\r")); marker != "" {
+			if fence == "" {
+				fence = marker
+			} else if strings.HasPrefix(marker, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+		lines[i] = mapOutsideInlineCode(lines[i], fn)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// mapOutsideInlineCode applies fn to the parts of a line outside `code`
+// spans. A span closes at the next run of as many backticks as opened it.
+func mapOutsideInlineCode(line string, fn func(text string) string) string {
+	var b strings.Builder
+	start := 0
+	for i := 0; i < len(line); {
+		if line[i] != '`' {
+			i++
+			continue
+		}
+		run := len(line[i:]) - len(strings.TrimLeft(line[i:], "`"))
+		closing := strings.Index(line[i+run:], line[i:i+run])
+		if closing == -1 {
+			i += run
+			continue
+		}
+		b.WriteString(fn(line[start:i]))
+		end := i + run + closing + run
+		b.WriteString(line[i:end])
+		start, i = end, end
+	}
+	b.WriteString(fn(line[start:]))
+	return b.String()
+}
@@ -0,0 +1,162 @@
+package obsidian_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoteTags(t *testing.T) {
+	t.Run("Finds frontmatter and inline tags outside code", func(t *testing.T) {
+		// Arrange
+		content := "---\ntags: [project, \"#status/open\"]\n---\n" +
+			"# Heading\n" +
+			"Working on #proj/alpha and #todo, not #123.\n" +
+			"See [[Note#Heading]], [section](#heading) and https://example.com/page#frag\n" +
+			"Inline `#code` is skipped, #after is not\n" +
+			"```\n#fenced\n```\n"
+		// Act
+		tags := obsidian.NoteTags(content)
+		// Assert
+		assert.Equal(t, []string{"project", "status/open", "proj/alpha", "todo", "after"}, tags)
+	})
+
+	t.Run("String form of frontmatter tags", func(t *testing.T) {
+		tags := obsidian.NoteTags("---\ntags: one, two three\n---\n")
+		assert.Equal(t, []string{"one", "two", "three"}, tags)
+	})
+}
+
+func TestTagTree(t *testing.T) {
+	// Arrange
+	counts := []obsidian.TagCount{
+		{Name: "proj", Count: 1},
+		{Name: "proj/alpha", Count: 2},
+		{Name: "proj/beta/x", Count: 3},
+		{Name: "todo", Count: 4},
+	}
+	// Act
+	tree := obsidian.TagTree(counts)
+	// Assert
+	assert.Len(t, tree, 2)
+	assert.Equal(t, "proj", tree[0].Name)
+	assert.Equal(t, 1, tree[0].Count)
+	assert.Equal(t, 6, tree[0].Total)
+	assert.Len(t, tree[0].Children, 2)
+	assert.Equal(t, "beta", tree[0].Children[1].Name)
+	assert.Equal(t, 0, tree[0].Children[1].Count)
+	assert.Equal(t, 3, tree[0].Children[1].Total)
+	assert.Equal(t, "proj/beta/x", tree[0].Children[1].Children[0].Path)
+}
+
+func TestRenameTagsInNote(t *testing.T) {
+	t.Run("Renames inline tags and nested tags", func(t *testing.T) {
+		// Arrange
+		content := "#proj at the start, #proj/alpha nested, #project untouched\n" +
+			"`#proj` in code and https://example.com/#proj in a URL\n" +
+			"```\n#proj\n```\n"
+		// Act
+		updated := obsidian.RenameTagsInNote(content, []string{"proj"}, "work")
+		// Assert
+		assert.Equal(t, "#work at the start, #work/alpha nested, #project untouched\n"+
+			"`#proj` in code and https://example.com/#proj in a URL\n"+
+			"```\n#proj\n```\n", updated)
+	})
+
+	t.Run("Renames frontmatter lists in every form", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			content  string
+			expected string
+		}{
+			{"Flow list", "---\ntags: [a, \"#Proj\", b]\n---\n", "---\ntags: [a, \"#work\", b]\n---\n"},
+			{"String", "---\ntags: a, proj/x\n---\n", "---\ntags: a, work/x\n---\n"},
+			{"Space separated string", "---\ntags: a proj\n---\n", "---\ntags: a work\n---\n"},
+			{"Block list", "---\ntitle: x\ntags:\n  - a\n  - proj\n---\n", "---\ntitle: x\ntags:\n  - a\n  - work\n---\n"},
+			{"Unchanged", "---\ntags:   [a,b]\n---\n", "---\ntags:   [a,b]\n---\n"},
+			{"Other properties", "---\naliases: [proj]\n---\n", "---\naliases: [proj]\n---\n"},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				assert.Equal(t, test.expected, obsidian.RenameTagsInNote(test.content, []string{"proj"}, "work"))
+			})
+		}
+	})
+
+	t.Run("Merging removes duplicates", func(t *testing.T) {
+		// Act
+		flow := obsidian.RenameTagsInNote("---\ntags: [todo, to-do, x]\n---\n", []string{"todo", "to-do"}, "task")
+		block := obsidian.RenameTagsInNote("---\ntags:\n- todo\n- task\n- x\n---\n#todo\r\n", []string{"todo"}, "task")
+		// Assert
+		assert.Equal(t, "---\ntags: [task, x]\n---\n", flow)
+		assert.Equal(t, "---\ntags:\n- task\n- x\n---\n#task\r\n", block)
+	})
+}
+
+func TestRenameTags(t *testing.T) {
+	t.Run("Rewrites notes using the tag", func(t *testing.T) {
+		// Arrange
+		vaultPath := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(vaultPath, "a.md"), []byte("#old\n"), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(vaultPath, "b.md"), []byte("#other\n"), 0644))
+		// Act
+		changed, err := obsidian.RenameTags(vaultPath, []string{"#old"}, "new")
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.md"}, changed)
+		content, _ := os.ReadFile(filepath.Join(vaultPath, "a.md"))
+		assert.Equal(t, "#new\n", string(content))
+	})
+
+	t.Run("Invalid target tag", func(t *testing.T) {
+		_, err := obsidian.RenameTags(t.TempDir(), []string{"old"}, "two words")
+		assert.EqualError(t, err, obsidian.InvalidTagError)
+	})
+}
+
+func TestAddTagsToNote(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"Note without frontmatter", "# Note\n", "---\ntags:\n  - inbox\n  - todo\n---\n# Note\n"},
+		{"Frontmatter without tags", "---\ntitle: Note\n---\n", "---\ntitle: Note\ntags:\n  - inbox\n  - todo\n---\n"},
+		{"Block list keeps its indent", "---\ntags:\n- a\n- b\ntitle: x\n---\n", "---\ntags:\n- a\n- b\n- inbox\n- todo\ntitle: x\n---\n"},
+		{"Flow list", "---\ntags: [a, b]\n---\n", "---\ntags: [a, b, inbox, todo]\n---\n"},
+		{"Comma separated string", "---\ntags: a, b\n---\n", "---\ntags: a, b, inbox, todo\n---\n"},
+		{"Tags the note already has are skipped", "---\ntags: [Inbox]\n---\n#todo\n", "---\ntags: [Inbox]\n---\n#todo\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			updated := obsidian.AddTagsToNote(test.content, []string{"inbox", "todo"})
+			// Assert
+			assert.Equal(t, test.expected, updated)
+		})
+	}
+}
+
+func TestAddTags(t *testing.T) {
+	t.Run("Adds tags to the notes given", func(t *testing.T) {
+		// Arrange
+		vaultPath := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(vaultPath, "a.md"), []byte("A\n"), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(vaultPath, "b.md"), []byte("#inbox\n"), 0644))
+		// Act
+		changed, err := obsidian.AddTags(vaultPath, []string{"a.md", "b.md"}, []string{"#inbox"})
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.md"}, changed)
+		content, _ := os.ReadFile(filepath.Join(vaultPath, "a.md"))
+		assert.Equal(t, "---\ntags:\n  - inbox\n---\nA\n", string(content))
+	})
+
+	t.Run("Invalid tag", func(t *testing.T) {
+		_, err := obsidian.AddTags(t.TempDir(), []string{"a.md"}, []string{"two words"})
+		assert.EqualError(t, err, obsidian.InvalidTagError)
+	})
+}
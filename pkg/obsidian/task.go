@@ -0,0 +1,363 @@
+package obsidian
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TaskStatusTodo      = "todo"
+	TaskStatusDone      = "done"
+	TaskStatusCancelled = "cancelled"
+	TaskStatusAll       = "all"
+)
+
+// Task is a checklist item (- [ ] or - [x]) found in a note, along with the
+// metadata understood by the Obsidian Tasks plugin.
+type Task struct {
+	ID          string
+	FilePath    string
+	LineNumber  int
+	Line        string
+	Status      string
+	Description string
+	Tags        []string
+	Priority    string
+	Recurrence  string
+	Due         time.Time
+	Scheduled   time.Time
+	Start       time.Time
+	Created     time.Time
+	Done        time.Time
+	Cancelled   time.Time
+}
+
+// IsDone reports whether the task is checked off.
+func (t Task) IsDone() bool {
+	return t.Status == "x" || t.Status == "X"
+}
+
+// IsCancelled reports whether the task is marked cancelled with [-].
+func (t Task) IsCancelled() bool {
+	return t.Status == "-"
+}
+
+// IsMigrated reports whether the task was carried over to another note and
+// marked [>].
+func (t Task) IsMigrated() bool {
+	return t.Status == ">"
+}
+
+// IsOpen reports whether the task still needs doing. Custom statuses such as
+// [/] (in progress) count as open.
+func (t Task) IsOpen() bool {
+	return !t.IsDone() && !t.IsCancelled() && !t.IsMigrated()
+}
+
+// TaskFilter selects tasks for listing. Empty fields and zero dates match
+// every task.
+type TaskFilter struct {
+	Status    string
+	Note      string
+	Folder    string
+	Tag       string
+	DueOn     time.Time
+	DueBefore time.Time
+	DueAfter  time.Time
+}
+
+var (
+	taskPattern           = regexp.MustCompile(`^([ \t>]*(?:[-*+]|\d+[.)]) +)\[([^\[\]])\](?:[ \t]+(.*))?$`)
+	taskDuePattern        = taskDatePattern(`📅|📆|🗓`)
+	taskScheduledPattern  = taskDatePattern(`⏳|⌛`)
+	taskStartPattern      = taskDatePattern(`🛫`)
+	taskCreatedPattern    = taskDatePattern(`➕`)
+	taskDonePattern       = taskDatePattern(`✅`)
+	taskCancelledPattern  = taskDatePattern(`❌`)
+	taskRecurrencePattern = regexp.MustCompile(`[ \t]*🔁\x{FE0F}?[ \t]*([A-Za-z0-9, !]*[A-Za-z0-9!])`)
+	taskPriorityPattern   = regexp.MustCompile(`[ \t]*(🔺|⏫|🔼|🔽|⏬)\x{FE0F}?`)
+	taskBlockIDPattern    = regexp.MustCompile(`[ \t]+\^[A-Za-z0-9-]+$`)
+)
+
+var taskPriorities = map[string]string{
+	"🔺": "highest",
+	"⏫": "high",
+	"🔼": "medium",
+	"🔽": "low",
+	"⏬": "lowest",
+}
+
+func taskDatePattern(signifiers string) *regexp.Regexp {
+	return regexp.MustCompile(`[ \t]*(?:` + signifiers + `)\x{FE0F}?[ \t]*(\d{4}-\d{2}-\d{2})`)
+}
+
+// ParseTasks returns the tasks in a note, skipping the frontmatter and fenced
+// code blocks. relPath is the vault relative path of the note.
+func ParseTasks(relPath string, content string) []Task {
+	var tasks []Task
+	lines := strings.Split(content, "\n")
+	fence := ""
+	for i := frontmatterEnd(lines); i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r")
+		if marker := codeFenceMarker(line); marker != "" {
+			if fence == "" {
+				fence = marker
+			} else if strings.HasPrefix(marker, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+
+		if task, ok := ParseTask(line); ok {
+			task.FilePath = relPath
+			task.LineNumber = i + 1
+			task.ID = TaskID(relPath, i+1)
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// ParseTask parses a single checklist line. The returned task has no
+// location set.
+func ParseTask(line string) (Task, bool) {
+	match := taskPattern.FindStringSubmatch(line)
+	if match == nil {
+		return Task{}, false
+	}
+
+	text := match[3]
+	task := Task{
+		Line:   line,
+		Status: match[2],
+		Tags:   InlineTags(text),
+	}
+	task.Due = parseTaskDate(taskDuePattern, text)
+	task.Scheduled = parseTaskDate(taskScheduledPattern, text)
+	task.Start = parseTaskDate(taskStartPattern, text)
+	task.Created = parseTaskDate(taskCreatedPattern, text)
+	task.Done = parseTaskDate(taskDonePattern, text)
+	task.Cancelled = parseTaskDate(taskCancelledPattern, text)
+	if m := taskRecurrencePattern.FindStringSubmatch(text); m != nil {
+		task.Recurrence = strings.TrimSpace(m[1])
+	}
+	if m := taskPriorityPattern.FindStringSubmatch(text); m != nil {
+		task.Priority = taskPriorities[m[1]]
+	}
+
+	description := taskBlockIDPattern.ReplaceAllString(text, "")
+	for _, pattern := range []*regexp.Regexp{
+		taskDuePattern, taskScheduledPattern, taskStartPattern, taskCreatedPattern,
+		taskDonePattern, taskCancelledPattern, taskRecurrencePattern, taskPriorityPattern,
+	} {
+		description = pattern.ReplaceAllString(description, "")
+	}
+	task.Description = strings.Join(strings.Fields(description), " ")
+	return task, true
+}
+
+func parseTaskDate(pattern *regexp.Regexp, text string) time.Time {
+	match := pattern.FindStringSubmatch(text)
+	if match == nil {
+		return time.Time{}
+	}
+	date, err := time.ParseInLocation(DateLayout, match[1], time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return date
+}
+
+// TaskID returns the short ID of the task on a line of a note, as shown by
+// tasks list.
+func TaskID(relPath string, lineNumber int) string {
+	return StableID(relPath, lineNumber)[:7]
+}
+
+// StableID identifies a line of a note by its path and line number, so the ID
+// stays the same between runs as long as the note is not edited above it.
+func StableID(relPath string, lineNumber int) string {
+	sum := sha1.Sum([]byte(filepath.ToSlash(relPath) + ":" + strconv.Itoa(lineNumber)))
+	return hex.EncodeToString(sum[:])
+}
+
+// FindTasks returns every task in the vault, in note path order.
+func FindTasks(vaultPath string) ([]Task, error) {
+	var tasks []Task
+	err := WalkNotes(vaultPath, func(relPath string, content []byte) error {
+		tasks = append(tasks, ParseTasks(relPath, string(content))...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// FindTaskByID looks up a task by the ID shown by ListTasks.
+func FindTaskByID(vaultPath string, id string) (Task, error) {
+	tasks, err := FindTasks(vaultPath)
+	if err != nil {
+		return Task{}, err
+	}
+	for _, task := range tasks {
+		if strings.EqualFold(task.ID, id) {
+			return task, nil
+		}
+	}
+	return Task{}, errors.New(TaskNotFoundError)
+}
+
+// ValidTaskStatus reports whether status can be used in a TaskFilter.
+func ValidTaskStatus(status string) bool {
+	switch status {
+	case "", TaskStatusTodo, TaskStatusDone, TaskStatusCancelled, TaskStatusAll:
+		return true
+	}
+	return false
+}
+
+// Matches reports whether task passes every condition of the filter. A task
+// status filter of "" means todo.
+func (f TaskFilter) Matches(task Task) bool {
+	switch f.Status {
+	case "", TaskStatusTodo:
+		if !task.IsOpen() {
+			return false
+		}
+	case TaskStatusDone:
+		if !task.IsDone() {
+			return false
+		}
+	case TaskStatusCancelled:
+		if !task.IsCancelled() {
+			return false
+		}
+	}
+
+	notePath := RemoveMdSuffix(filepath.ToSlash(task.FilePath))
+	if f.Note != "" {
+		note := RemoveMdSuffix(strings.Trim(filepath.ToSlash(f.Note), "/"))
+		if !strings.EqualFold(notePath, note) && !strings.EqualFold(filepath.Base(notePath), note) {
+			return false
+		}
+	}
+	if f.Folder != "" {
+		folder := strings.Trim(filepath.ToSlash(f.Folder), "/") + "/"
+		if !strings.HasPrefix(strings.ToLower(notePath), strings.ToLower(folder)) {
+			return false
+		}
+	}
+	if f.Tag != "" && !HasTag(task.Tags, f.Tag) {
+		return false
+	}
+
+	if !f.DueOn.IsZero() || !f.DueBefore.IsZero() || !f.DueAfter.IsZero() {
+		if task.Due.IsZero() {
+			return false
+		}
+		due := truncateDay(task.Due)
+		if !f.DueOn.IsZero() && !due.Equal(truncateDay(f.DueOn)) {
+			return false
+		}
+		if !f.DueBefore.IsZero() && !due.Before(truncateDay(f.DueBefore)) {
+			return false
+		}
+		if !f.DueAfter.IsZero() && !due.After(truncateDay(f.DueAfter)) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasTag matches tag against tags case-insensitively. A parent tag also
+// matches its nested tags, so #project matches #project/alpha.
+func HasTag(tags []string, tag string) bool {
+	tag = strings.ToLower(strings.TrimPrefix(tag, "#"))
+	for _, t := range tags {
+		t = strings.ToLower(t)
+		if t == tag || strings.HasPrefix(t, tag+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// CompleteTaskLine checks off a task line and records the done date the way
+// the Tasks plugin does, before any trailing block ID.
+func CompleteTaskLine(line string, date time.Time) string {
+	line = setTaskStatus(taskDonePattern.ReplaceAllString(line, ""), "x")
+	doneDate := " ✅ " + date.Format(DateLayout)
+	if loc := taskBlockIDPattern.FindStringIndex(line); loc != nil {
+		return line[:loc[0]] + doneDate + line[loc[0]:]
+	}
+	return strings.TrimRight(line, " \t") + doneDate
+}
+
+// ReopenTaskLine unchecks a task line and removes its done and cancelled
+// dates.
+func ReopenTaskLine(line string) string {
+	line = taskDonePattern.ReplaceAllString(line, "")
+	line = taskCancelledPattern.ReplaceAllString(line, "")
+	return setTaskStatus(line, " ")
+}
+
+func setTaskStatus(line string, status string) string {
+	match := taskPattern.FindStringSubmatchIndex(line)
+	if match == nil {
+		return line
+	}
+	return line[:match[4]] + status + line[match[5]:]
+}
+
+// ReplaceTaskLine replaces the line of task in its note with lines. It fails
+// if the note has changed so that the line no longer holds the task.
+func ReplaceTaskLine(vaultPath string, task Task, lines ...string) error {
+	notePath := filepath.Join(vaultPath, task.FilePath)
+	info, err := os.Stat(notePath)
+	if err != nil {
+		return errors.New(NoteDoesNotExistError)
+	}
+	content, err := os.ReadFile(notePath)
+	if err != nil {
+		return errors.New(VaultReadError)
+	}
+
+	noteLines := strings.Split(string(content), "\n")
+	index := task.LineNumber - 1
+	if index < 0 || index >= len(noteLines) || strings.TrimRight(noteLines[index], "\r") != task.Line {
+		return errors.New(TaskChangedError)
+	}
+
+	lineEnding := ""
+	if strings.HasSuffix(noteLines[index], "\r") {
+		lineEnding = "\r"
+	}
+	replacement := make([]string, len(lines))
+	for i, line := range lines {
+		replacement[i] = line + lineEnding
+	}
+
+	updated := append([]string{}, noteLines[:index]...)
+	updated = append(updated, replacement...)
+	updated = append(updated, noteLines[index+1:]...)
+	err = os.WriteFile(notePath, []byte(strings.Join(updated, "\n")), info.Mode())
+	if err != nil {
+		return errors.New(VaultWriteError)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package obsidian_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+func TestParseTask(t *testing.T) {
+	t.Run("Parses Tasks plugin metadata", func(t *testing.T) {
+		// Act
+		task, ok := obsidian.ParseTask("  - [ ] Pay rent #home ⏫ 🔁 every month 📅 2024-02-01 ⏳️ 2024-01-30 ^rent")
+		// Assert
+		assert.True(t, ok)
+		assert.Equal(t, " ", task.Status)
+		assert.Equal(t, "Pay rent #home", task.Description)
+		assert.Equal(t, []string{"home"}, task.Tags)
+		assert.Equal(t, "high", task.Priority)
+		assert.Equal(t, "every month", task.Recurrence)
+		assert.Equal(t, date(2024, 2, 1), task.Due)
+		assert.Equal(t, date(2024, 1, 30), task.Scheduled)
+		assert.True(t, task.IsOpen())
+	})
+
+	t.Run("Parses done and cancelled tasks", func(t *testing.T) {
+		done, _ := obsidian.ParseTask("1. [x] Ship it ✅ 2024-01-05")
+		cancelled, _ := obsidian.ParseTask("* [-] Drop it")
+		assert.True(t, done.IsDone())
+		assert.Equal(t, date(2024, 1, 5), done.Done)
+		assert.True(t, cancelled.IsCancelled())
+	})
+
+	t.Run("Ignores lines that are not tasks", func(t *testing.T) {
+		for _, line := range []string{"- plain item", "[ ] no marker", "- [link](url)", "-[ ] no space"} {
+			_, ok := obsidian.ParseTask(line)
+			assert.False(t, ok, line)
+		}
+	})
+}
+
+func TestParseTasks(t *testing.T) {
+	t.Run("Skips code blocks and sets location", func(t *testing.T) {
+		// Arrange
+		content := "# Todo\n- [ ] one\n```\n- [ ] code\n```\n- [x] two\n"
+		// Act
+		tasks := obsidian.ParseTasks("notes/todo.md", content)
+		// Assert
+		assert.Len(t, tasks, 2)
+		assert.Equal(t, 2, tasks[0].LineNumber)
+		assert.Equal(t, 6, tasks[1].LineNumber)
+		assert.Equal(t, "notes/todo.md", tasks[1].FilePath)
+		assert.Len(t, tasks[0].ID, 7)
+		assert.NotEqual(t, tasks[0].ID, tasks[1].ID)
+	})
+}
+
+func TestTaskFilterMatches(t *testing.T) {
+	open := obsidian.Task{FilePath: "Projects/Apollo.md", Status: " ", Tags: []string{"work/apollo"}, Due: date(2024, 1, 10)}
+	done := obsidian.Task{FilePath: "Inbox.md", Status: "x"}
+
+	tests := []struct {
+		name   string
+		filter obsidian.TaskFilter
+		task   obsidian.Task
+		want   bool
+	}{
+		{"Default status is todo", obsidian.TaskFilter{}, done, false},
+		{"Done status", obsidian.TaskFilter{Status: obsidian.TaskStatusDone}, done, true},
+		{"All status", obsidian.TaskFilter{Status: obsidian.TaskStatusAll}, done, true},
+		{"Note by name", obsidian.TaskFilter{Note: "apollo"}, open, true},
+		{"Note by path", obsidian.TaskFilter{Note: "Projects/Apollo.md"}, open, true},
+		{"Other note", obsidian.TaskFilter{Note: "Inbox"}, open, false},
+		{"Folder", obsidian.TaskFilter{Folder: "projects/"}, open, true},
+		{"Other folder", obsidian.TaskFilter{Folder: "Areas"}, open, false},
+		{"Parent tag", obsidian.TaskFilter{Tag: "#work"}, open, true},
+		{"Tag prefix is not a parent", obsidian.TaskFilter{Tag: "wor"}, open, false},
+		{"Due on", obsidian.TaskFilter{DueOn: date(2024, 1, 10).Add(15 * time.Hour)}, open, true},
+		{"Due before is exclusive", obsidian.TaskFilter{DueBefore: date(2024, 1, 10)}, open, false},
+		{"Due after", obsidian.TaskFilter{DueAfter: date(2024, 1, 9)}, open, true},
+		{"Due filter skips undated tasks", obsidian.TaskFilter{Status: obsidian.TaskStatusAll, DueAfter: date(2024, 1, 9)}, done, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, test.filter.Matches(test.task))
+		})
+	}
+}
+
+func TestCompleteTaskLine(t *testing.T) {
+	t.Run("Adds done date", func(t *testing.T) {
+		line := obsidian.CompleteTaskLine("- [ ] Write report 📅 2024-01-10", date(2024, 1, 9))
+		assert.Equal(t, "- [x] Write report 📅 2024-01-10 ✅ 2024-01-09", line)
+	})
+
+	t.Run("Keeps block ID last", func(t *testing.T) {
+		line := obsidian.CompleteTaskLine("- [ ] Write report ^abc", date(2024, 1, 9))
+		assert.Equal(t, "- [x] Write report ✅ 2024-01-09 ^abc", line)
+	})
+
+	t.Run("Reopen removes done date", func(t *testing.T) {
+		line := obsidian.ReopenTaskLine("- [x] Write report ✅ 2024-01-09 ^abc")
+		assert.Equal(t, "- [ ] Write report ^abc", line)
+	})
+}
+
+func TestReplaceTaskLine(t *testing.T) {
+	t.Run("Replaces line keeping CRLF endings", func(t *testing.T) {
+		// Arrange
+		vaultPath := mocks.CreateVaultFiles(t, map[string]string{"todo.md": "a\r\n- [ ] task\r\nb\r\n"})
+		task := obsidian.ParseTasks("todo.md", readVaultFile(t, vaultPath, "todo.md"))[0]
+		// Act
+		err := obsidian.ReplaceTaskLine(vaultPath, task, "- [x] task")
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "a\r\n- [x] task\r\nb\r\n", readVaultFile(t, vaultPath, "todo.md"))
+	})
+
+	t.Run("Fails when the note changed", func(t *testing.T) {
+		// Arrange
+		vaultPath := mocks.CreateVaultFiles(t, map[string]string{"todo.md": "- [ ] task\n"})
+		task := obsidian.ParseTasks("todo.md", "new line\n- [ ] task\n")[0]
+		// Act
+		err := obsidian.ReplaceTaskLine(vaultPath, task, "- [x] task")
+		// Assert
+		assert.EqualError(t, err, obsidian.TaskChangedError)
+	})
+}
@@ -0,0 +1,172 @@
+package obsidian
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/config"
+)
+
+const (
+	DefaultDateFormat = "YYYY-MM-DD"
+	DefaultTimeFormat = "HH:mm"
+)
+
+// TemplatesConfig mirrors the settings of Obsidian's Templates core plugin,
+// stored in .obsidian/templates.json.
+type TemplatesConfig struct {
+	Folder     string `json:"folder"`
+	DateFormat string `json:"dateFormat"`
+	TimeFormat string `json:"timeFormat"`
+}
+
+// TemplateData holds the values substituted into a template.
+type TemplateData struct {
+	Title      string
+	Date       time.Time
+	DateFormat string
+	TimeFormat string
+	Variables  map[string]string
+}
+
+var templateVariablePattern = regexp.MustCompile(`{{\s*([A-Za-z0-9_-]+)\s*(?::([^}]*))?}}`)
+
+// RenderTemplate replaces the {{title}}, {{date}} and {{time}} variables
+// understood by Obsidian's core Templates plugin. Date and time accept a
+// moment.js format override, e.g. {{date:dddd D MMMM}}. Any other variable is
+// looked up in data.Variables and left untouched when it is not set.
+func RenderTemplate(content string, data TemplateData) string {
+	dateFormat := data.DateFormat
+	if dateFormat == "" {
+		dateFormat = DefaultDateFormat
+	}
+	timeFormat := data.TimeFormat
+	if timeFormat == "" {
+		timeFormat = DefaultTimeFormat
+	}
+
+	return templateVariablePattern.ReplaceAllStringFunc(content, func(match string) string {
+		parts := templateVariablePattern.FindStringSubmatch(match)
+		name, format := parts[1], strings.TrimSpace(parts[2])
+		switch strings.ToLower(name) {
+		case "title":
+			return data.Title
+		case "date":
+			if format == "" {
+				format = dateFormat
+			}
+			return FormatMomentDate(data.Date, format)
+		case "time":
+			if format == "" {
+				format = timeFormat
+			}
+			return FormatMomentDate(data.Date, format)
+		}
+		if value, ok := data.Variables[name]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// TemplateVariables returns the names of the custom variables used in a
+// template, in order of first use, leaving out title, date and time.
+func TemplateVariables(content string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, parts := range templateVariablePattern.FindAllStringSubmatch(content, -1) {
+		name := parts[1]
+		switch strings.ToLower(name) {
+		case "title", "date", "time":
+			continue
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ReadTemplate reads a template from a vault relative path, with or without
+// the .md suffix.
+func ReadTemplate(vaultPath string, templatePath string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(vaultPath, AddMdSuffix(templatePath)))
+	if err != nil {
+		return "", errors.New(TemplateReadError)
+	}
+	return string(content), nil
+}
+
+// ReadTemplatesConfig reads the Templates core plugin settings of the vault.
+// A vault without the settings file has no templates folder.
+func ReadTemplatesConfig(vaultPath string) (TemplatesConfig, error) {
+	templatesConfig := TemplatesConfig{}
+
+	content, err := os.ReadFile(filepath.Join(vaultPath, config.VaultConfigDirectory, config.TemplatesConfigFile))
+	if err == nil {
+		err = json.Unmarshal(content, &templatesConfig)
+		if err != nil {
+			return TemplatesConfig{}, errors.New(TemplatesConfigParseError)
+		}
+	} else if !os.IsNotExist(err) {
+		return TemplatesConfig{}, errors.New(TemplatesConfigParseError)
+	}
+
+	templatesConfig.Folder = strings.Trim(templatesConfig.Folder, "/")
+	return templatesConfig, nil
+}
+
+// ListTemplates returns the templates in the configured templates folder as
+// paths relative to that folder, without the .md suffix.
+func ListTemplates(vaultPath string, templatesConfig TemplatesConfig) ([]string, error) {
+	if templatesConfig.Folder == "" {
+		return nil, errors.New(TemplatesFolderNotSetError)
+	}
+
+	templatesPath := filepath.Join(vaultPath, templatesConfig.Folder)
+	var templates []string
+	err := filepath.WalkDir(templatesPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(d.Name(), ".md") {
+			relPath, err := filepath.Rel(templatesPath, path)
+			if err != nil {
+				return err
+			}
+			templates = append(templates, RemoveMdSuffix(filepath.ToSlash(relPath)))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.New(VaultReadError)
+	}
+
+	sort.Strings(templates)
+	return templates, nil
+}
+
+// FindTemplate matches a template name against the available templates,
+// first by path and then by file name, ignoring case and the .md suffix.
+func FindTemplate(templates []string, name string) (string, error) {
+	name = RemoveMdSuffix(strings.Trim(filepath.ToSlash(name), "/"))
+	for _, template := range templates {
+		if strings.EqualFold(template, name) {
+			return template, nil
+		}
+	}
+	for _, template := range templates {
+		if strings.EqualFold(filepath.Base(template), name) {
+			return template, nil
+		}
+	}
+	return "", errors.New(TemplateNotFoundError)
+}
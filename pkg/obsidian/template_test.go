@@ -0,0 +1,162 @@
+package obsidian_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Yakitrak/obsidian-cli/mocks"
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	data := obsidian.TemplateData{
+		Title: "My Note",
+		Date:  time.Date(2024, time.June, 9, 14, 5, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		testName string
+		content  string
+		want     string
+	}{
+		{"Title", "# {{title}}", "# My Note"},
+		{"Default date and time formats", "{{date}} {{time}}", "2024-06-09 14:05"},
+		{"Custom date format", "{{date:dddd D MMMM}}", "Sunday 9 June"},
+		{"Custom time format", "{{ time:h A }}", "2 PM"},
+		{"Case insensitive", "{{Title}}", "My Note"},
+		{"Unknown variables are left untouched", "{{author}}", "{{author}}"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			// Act
+			got := obsidian.RenderTemplate(test.content, data)
+			// Assert
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("Uses configured date format", func(t *testing.T) {
+		// Act
+		got := obsidian.RenderTemplate("{{date}}", obsidian.TemplateData{Date: data.Date, DateFormat: "DD.MM.YYYY"})
+		// Assert
+		assert.Equal(t, "09.06.2024", got)
+	})
+}
+
+func TestReadTemplate(t *testing.T) {
+	t.Run("Reads template with or without .md", func(t *testing.T) {
+		// Arrange
+		vaultPath := t.TempDir()
+		err := os.MkdirAll(filepath.Join(vaultPath, "Templates"), 0755)
+		assert.NoError(t, err)
+		err = os.WriteFile(filepath.Join(vaultPath, "Templates", "Daily.md"), []byte("template"), 0644)
+		assert.NoError(t, err)
+		// Act
+		withoutSuffix, err1 := obsidian.ReadTemplate(vaultPath, "Templates/Daily")
+		withSuffix, err2 := obsidian.ReadTemplate(vaultPath, "Templates/Daily.md")
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Equal(t, "template", withoutSuffix)
+		assert.Equal(t, "template", withSuffix)
+	})
+
+	t.Run("Missing template", func(t *testing.T) {
+		// Act
+		_, err := obsidian.ReadTemplate(t.TempDir(), "missing")
+		// Assert
+		assert.Equal(t, obsidian.TemplateReadError, err.Error())
+	})
+}
+
+func TestRenderTemplateVariables(t *testing.T) {
+	t.Run("Substitutes custom variables", func(t *testing.T) {
+		// Arrange
+		data := obsidian.TemplateData{Variables: map[string]string{"project": "Apollo", "owner": ""}}
+		// Act
+		got := obsidian.RenderTemplate("{{project}} by {{owner}} {{missing}}", data)
+		// Assert
+		assert.Equal(t, "Apollo by  {{missing}}", got)
+	})
+
+	t.Run("Lists custom variables in order", func(t *testing.T) {
+		// Act
+		got := obsidian.TemplateVariables("{{title}} {{project}} {{date:YYYY}} {{ owner }} {{project}}")
+		// Assert
+		assert.Equal(t, []string{"project", "owner"}, got)
+	})
+}
+
+func TestListTemplates(t *testing.T) {
+	t.Run("Lists templates in configured folder", func(t *testing.T) {
+		// Arrange
+		vaultPath := mocks.CreateVaultFiles(t, map[string]string{
+			".obsidian/templates.json":  `{"folder":"Templates/","dateFormat":"DD/MM/YYYY"}`,
+			"Templates/Meeting.md":      "",
+			"Templates/Work/Standup.md": "",
+			"Templates/image.png":       "",
+		})
+		// Act
+		templatesConfig, err := obsidian.ReadTemplatesConfig(vaultPath)
+		assert.NoError(t, err)
+		templates, err := obsidian.ListTemplates(vaultPath, templatesConfig)
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, obsidian.TemplatesConfig{Folder: "Templates", DateFormat: "DD/MM/YYYY"}, templatesConfig)
+		assert.Equal(t, []string{"Meeting", "Work/Standup"}, templates)
+	})
+
+	t.Run("Templates folder not configured", func(t *testing.T) {
+		// Arrange
+		vaultPath := t.TempDir()
+		templatesConfig, err := obsidian.ReadTemplatesConfig(vaultPath)
+		assert.NoError(t, err)
+		// Act
+		_, err = obsidian.ListTemplates(vaultPath, templatesConfig)
+		// Assert
+		assert.Equal(t, obsidian.TemplatesFolderNotSetError, err.Error())
+	})
+
+	t.Run("Invalid settings file", func(t *testing.T) {
+		// Arrange
+		vaultPath := mocks.CreateVaultFiles(t, map[string]string{".obsidian/templates.json": `nope`})
+		// Act
+		_, err := obsidian.ReadTemplatesConfig(vaultPath)
+		// Assert
+		assert.Equal(t, obsidian.TemplatesConfigParseError, err.Error())
+	})
+}
+
+func TestFindTemplate(t *testing.T) {
+	templates := []string{"Meeting", "Work/Standup"}
+	tests := []struct {
+		testName string
+		name     string
+		want     string
+	}{
+		{"Exact name", "Meeting", "Meeting"},
+		{"Case and suffix are ignored", "meeting.md", "Meeting"},
+		{"Path", "Work/Standup", "Work/Standup"},
+		{"File name in sub folder", "standup", "Work/Standup"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			// Act
+			got, err := obsidian.FindTemplate(templates, test.name)
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("Missing template", func(t *testing.T) {
+		// Act
+		_, err := obsidian.FindTemplate(templates, "retro")
+		// Assert
+		assert.Equal(t, obsidian.TemplateNotFoundError, err.Error())
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"github.com/skratchdot/open-golang/open"
 	"net/url"
+	"sort"
+	"strings"
 )
 
 type Uri struct {
@@ -15,19 +17,33 @@ type UriManager interface {
 }
 
 func (u *Uri) Construct(baseUri string, params map[string]string) string {
+	// Sort the keys so the same params always give the same URI.
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
 	uri := baseUri
-	for key, value := range params {
+	for _, key := range keys {
+		value := params[key]
 		if value != "" && value != "false" {
 			if uri == baseUri {
-				uri += "?" + key + "=" + url.PathEscape(value)
+				uri += "?" + key + "=" + encodeValue(value)
 			} else {
-				uri += "&" + key + "=" + url.PathEscape(value)
+				uri += "&" + key + "=" + encodeValue(value)
 			}
 		}
 	}
 	return uri
 }
 
+// encodeValue escapes a param value, including the & = and + that would end
+// or change it. Obsidian reads + as a plus, so spaces are sent as %20.
+func encodeValue(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
 var Run = open.Run
 
 func (u *Uri) Execute(uri string) error {
@@ -22,6 +22,7 @@ func TestUriConstruct(t *testing.T) {
 		{"Two keys", map[string]string{"key1": "value1", "key2": "value2"}, map[string]string{"key1": "value1", "key2": "value2"}},
 		{"Empty value", map[string]string{"key": ""}, nil},
 		{"Mix of empty and non-empty values", map[string]string{"key1": "value1", "key2": ""}, map[string]string{"key1": "value1"}},
+		{"Reserved characters", map[string]string{"query": "R&D a=b+c", "file": "notes/a b"}, map[string]string{"query": "R&D a=b+c", "file": "notes/a b"}},
 	}
 
 	for _, test := range tests {
@@ -49,6 +50,20 @@ func TestUriConstruct(t *testing.T) {
 	}
 }
 
+func TestUriConstructOrder(t *testing.T) {
+	t.Run("Params are sorted by key", func(t *testing.T) {
+		uriManager := obsidian.Uri{}
+		got := uriManager.Construct("base-uri", map[string]string{"vault": "v", "file": "f", "heading": "h"})
+		assert.Equal(t, "base-uri?file=f&heading=h&vault=v", got)
+	})
+
+	t.Run("Spaces are escaped as %20", func(t *testing.T) {
+		uriManager := obsidian.Uri{}
+		got := uriManager.Construct("obsidian://search", map[string]string{"vault": "My Vault", "query": "R&D notes"})
+		assert.Equal(t, "obsidian://search?query=R%26D%20notes&vault=My%20Vault", got)
+	})
+}
+
 func TestUriExecute(t *testing.T) {
 	// Temporarily override the Run function
 	originalOpenerFunc := obsidian.Run
@@ -1,7 +1,10 @@
 package obsidian
 
 type CliConfig struct {
-	DefaultVaultName string `json:"default_vault_name"`
+	DefaultVaultName string            `json:"default_vault_name"`
+	Editor           string            `json:"editor,omitempty"`
+	EditorCommand    string            `json:"editor_command,omitempty"`
+	EditorWaitFlags  map[string]string `json:"editor_wait_flags,omitempty"`
 }
 
 type ObsidianVaultConfig struct {
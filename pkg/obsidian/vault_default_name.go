@@ -44,31 +44,18 @@ func (v *Vault) DefaultName() (string, error) {
 }
 
 func (v *Vault) SetDefaultName(name string) error {
-	// marshal obsidian name to json
-	cliConfig := CliConfig{DefaultVaultName: name}
-	jsonContent, err := JsonMarshal(cliConfig)
+	// keep the other preferences, starting over when they cannot be read
+	cliConfig, err := ReadCliConfig()
 	if err != nil {
-		return errors.New(ObsidianCLIConfigGenerateJSONError)
+		cliConfig = CliConfig{}
 	}
 
-	// get cliConfig path
-	obsConfigDir, obsConfigFile, err := CliConfigPath()
+	cliConfig.DefaultVaultName = name
+	err = WriteCliConfig(cliConfig)
 	if err != nil {
 		return err
 	}
 
-	// create directory
-	err = os.MkdirAll(obsConfigDir, os.ModePerm)
-	if err != nil {
-		return errors.New(ObsidianCLIConfigDirWriteEror)
-	}
-
-	// create and write file
-	err = os.WriteFile(obsConfigFile, jsonContent, 0644)
-	if err != nil {
-		return errors.New(ObsidianCLIConfigWriteError)
-	}
-
 	v.Name = name
 
 	return nil
@@ -119,6 +119,40 @@ func TestVaultSetDefaultName(t *testing.T) {
 		assert.Equal(t, `{"default_vault_name":"vault-name"}`, string(content))
 	})
 
+	t.Run("Keeps the other preferences", func(t *testing.T) {
+		// Arrange
+		mockCliConfigDir, mockCliConfigFile := mocks.CreateMockCliConfigDirectories(t)
+		obsidian.CliConfigPath = func() (string, string, error) {
+			return mockCliConfigDir, mockCliConfigFile, nil
+		}
+		err := os.WriteFile(mockCliConfigFile, []byte(`{"default_vault_name":"old","editor_command":"{editor} {file}"}`), 0644)
+		vault := obsidian.Vault{}
+		// Act
+		err = vault.SetDefaultName("vault-name")
+		// Assert
+		assert.Equal(t, nil, err)
+		content, err := os.ReadFile(mockCliConfigFile)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, `{"default_vault_name":"vault-name","editor_command":"{editor} {file}"}`, string(content))
+	})
+
+	t.Run("Replaces preferences that cannot be parsed", func(t *testing.T) {
+		// Arrange
+		mockCliConfigDir, mockCliConfigFile := mocks.CreateMockCliConfigDirectories(t)
+		obsidian.CliConfigPath = func() (string, string, error) {
+			return mockCliConfigDir, mockCliConfigFile, nil
+		}
+		err := os.WriteFile(mockCliConfigFile, []byte(`{"default_vault_name":`), 0644)
+		vault := obsidian.Vault{}
+		// Act
+		err = vault.SetDefaultName("vault-name")
+		// Assert
+		assert.Equal(t, nil, err)
+		content, err := os.ReadFile(mockCliConfigFile)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, `{"default_vault_name":"vault-name"}`, string(content))
+	})
+
 	t.Run("Error in config.CliPath", func(t *testing.T) {
 		// Arrange
 		obsidian.CliConfigPath = func() (string, string, error) {
@@ -139,6 +173,10 @@ func TestVaultSetDefaultName(t *testing.T) {
 			return nil, errors.New("json marshal error")
 		}
 		// Arrange
+		mockCliConfigDir, mockCliConfigFile := mocks.CreateMockCliConfigDirectories(t)
+		obsidian.CliConfigPath = func() (string, string, error) {
+			return mockCliConfigDir, mockCliConfigFile, nil
+		}
 		vault := obsidian.Vault{}
 		// Act
 		err := vault.SetDefaultName("invalid json")
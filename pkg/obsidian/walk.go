@@ -0,0 +1,39 @@
+package obsidian
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WalkNotes calls fn with the vault relative path and content of every note
+// in the vault. Hidden files and folders, such as .obsidian and .trash, are
+// skipped.
+func WalkNotes(vaultPath string, fn func(relPath string, content []byte) error) error {
+	return filepath.WalkDir(vaultPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return errors.New(VaultAccessError)
+		}
+		if strings.HasPrefix(d.Name(), ".") && path != vaultPath {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || filepath.Ext(d.Name()) != ".md" {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(vaultPath, path)
+		if err != nil {
+			return errors.New(VaultAccessError)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return errors.New(VaultReadError)
+		}
+		return fn(relPath, content)
+	})
+}
@@ -0,0 +1,63 @@
+package obsidian
+
+import (
+	"regexp"
+	"strings"
+)
+
+// WikiLink is an Obsidian [[link]] or ![[embed]]. In [[Note#Heading|text]]
+// the target is Note, the subpath is Heading and the alias is text.
+type WikiLink struct {
+	Embed   bool
+	Target  string
+	Subpath string
+	Alias   string
+}
+
+var wikiLinkPattern = regexp.MustCompile(`(!?)\[\[([^\[\]|#]*)(?:#([^\[\]|]*))?(?:\|([^\[\]]*))?\]\]`)
+
+// ParseWikiLinks returns the wikilinks and embeds in text, in order.
+func ParseWikiLinks(text string) []WikiLink {
+	var links []WikiLink
+	for _, match := range wikiLinkPattern.FindAllStringSubmatch(text, -1) {
+		links = append(links, wikiLinkFromMatch(match))
+	}
+	return links
+}
+
+// ReplaceWikiLinks calls fn for every wikilink and embed in text and
+// replaces it with what fn returns.
+func ReplaceWikiLinks(text string, fn func(link WikiLink, original string) string) string {
+	return wikiLinkPattern.ReplaceAllStringFunc(text, func(original string) string {
+		return fn(wikiLinkFromMatch(wikiLinkPattern.FindStringSubmatch(original)), original)
+	})
+}
+
+func wikiLinkFromMatch(match []string) WikiLink {
+	return WikiLink{
+		Embed:   match[1] == "!",
+		Target:  strings.TrimSpace(match[2]),
+		Subpath: strings.TrimSpace(match[3]),
+		Alias:   match[4],
+	}
+}
+
+// String formats the link the way Obsidian writes it.
+func (l WikiLink) String() string {
+	var b strings.Builder
+	if l.Embed {
+		b.WriteString("!")
+	}
+	b.WriteString("[[")
+	b.WriteString(l.Target)
+	if l.Subpath != "" {
+		b.WriteString("#")
+		b.WriteString(l.Subpath)
+	}
+	if l.Alias != "" {
+		b.WriteString("|")
+		b.WriteString(l.Alias)
+	}
+	b.WriteString("]]")
+	return b.String()
+}
@@ -0,0 +1,30 @@
+package obsidian_test
+
+import (
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWikiLinks(t *testing.T) {
+	// Act
+	links := obsidian.ParseWikiLinks("See [[Note]], [[Folder/Note#Heading|text]] and ![[Other#^block]] or ![[pic.png]]")
+	// Assert
+	assert.Equal(t, []obsidian.WikiLink{
+		{Target: "Note"},
+		{Target: "Folder/Note", Subpath: "Heading", Alias: "text"},
+		{Embed: true, Target: "Other", Subpath: "^block"},
+		{Embed: true, Target: "pic.png"},
+	}, links)
+}
+
+func TestReplaceWikiLinks(t *testing.T) {
+	// Act
+	updated := obsidian.ReplaceWikiLinks("[[a#h|x]] and ![[b]]", func(link obsidian.WikiLink, original string) string {
+		link.Target = "new"
+		return link.String()
+	})
+	// Assert
+	assert.Equal(t, "[[new#h|x]] and ![[new]]", updated)
+}
@@ -0,0 +1,419 @@
+// Package render formats Markdown notes for reading in a terminal.
+package render
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/obsidian"
+	"github.com/mattn/go-runewidth"
+)
+
+// DefaultWidth is used when the width of the terminal is unknown.
+const DefaultWidth = 80
+
+const (
+	bold       = "\x1b[1m"
+	boldOff    = "\x1b[22m"
+	dim        = "\x1b[2m"
+	dimOff     = "\x1b[22m"
+	italic     = "\x1b[3m"
+	italicOff  = "\x1b[23m"
+	underline  = "\x1b[4m"
+	underOff   = "\x1b[24m"
+	reverse    = "\x1b[7m"
+	reverseOff = "\x1b[27m"
+	strike     = "\x1b[9m"
+	strikeOff  = "\x1b[29m"
+	colorOff   = "\x1b[39m"
+)
+
+const (
+	red     = "\x1b[31m"
+	green   = "\x1b[32m"
+	yellow  = "\x1b[33m"
+	blue    = "\x1b[34m"
+	magenta = "\x1b[35m"
+	cyan    = "\x1b[36m"
+	gray    = "\x1b[90m"
+)
+
+var (
+	ansiPattern           = regexp.MustCompile("\x1b\\[[0-9;]*m")
+	headingPattern        = regexp.MustCompile(`^ {0,3}(#{1,6})[ \t]+(.*?)(?:[ \t]+#+)?[ \t]*$`)
+	rulePattern           = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	fencePattern          = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})[ \t]*([^`\\s]*)")
+	listPattern           = regexp.MustCompile(`^([ \t]*)([-*+]|\d+[.)])[ \t]+(?:\[(.)\][ \t]+)?(.*)$`)
+	tableSeparatorPattern = regexp.MustCompile(`^[ \t]*\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	calloutPattern        = regexp.MustCompile(`^\[!([A-Za-z-]+)\][+-]?[ \t]*(.*)$`)
+	imagePattern          = regexp.MustCompile(`!\[([^\]]*)\]\(([^)]*)\)`)
+	linkPattern           = regexp.MustCompile(`\[([^\]]+)\]\(([^)]*)\)`)
+	boldPattern           = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	italicPattern         = regexp.MustCompile(`\*([^*\s][^*]*)\*|\b_([^_]+)_\b`)
+	strikePattern         = regexp.MustCompile(`~~([^~]+)~~`)
+	highlightPattern      = regexp.MustCompile(`==([^=]+)==`)
+	tagPattern            = regexp.MustCompile(`(^|\s)(#[\p{L}\p{N}_/\-]*[\p{L}_/\-][\p{L}\p{N}_/\-]*)`)
+)
+
+var calloutColors = map[string]string{
+	"note": blue, "info": blue, "todo": blue,
+	"abstract": cyan, "summary": cyan, "tldr": cyan,
+	"tip": green, "hint": green, "important": green, "success": green, "check": green, "done": green,
+	"question": yellow, "help": yellow, "faq": yellow, "warning": yellow, "caution": yellow, "attention": yellow,
+	"failure": red, "fail": red, "missing": red, "danger": red, "error": red, "bug": red,
+	"example": magenta,
+	"quote":   gray, "cite": gray,
+}
+
+// Markdown renders a note with ANSI styles, wrapped to width columns. The
+// frontmatter is left out.
+func Markdown(content string, width int) string {
+	if width <= 0 {
+		width = DefaultWidth
+	}
+	if _, body, err := obsidian.ParseFrontmatter(content); err == nil {
+		content = body
+	}
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	return strings.Join(renderLines(lines, width), "\n")
+}
+
+func renderLines(lines []string, width int) []string {
+	var out []string
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case fencePattern.MatchString(line):
+			match := fencePattern.FindStringSubmatch(line)
+			var code []string
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimLeft(lines[i], " "), match[1]) {
+					break
+				}
+				code = append(code, lines[i])
+			}
+			out = append(out, codeBox(match[2], code, width)...)
+
+		case i+1 < len(lines) && strings.Contains(line, "|") && tableSeparatorPattern.MatchString(lines[i+1]):
+			rows := []string{line, lines[i+1]}
+			for i += 2; i < len(lines) && strings.Contains(lines[i], "|"); i++ {
+				rows = append(rows, lines[i])
+			}
+			i--
+			out = append(out, table(rows)...)
+
+		case strings.HasPrefix(strings.TrimLeft(line, " "), ">"):
+			var quoted []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimLeft(lines[i], " "), ">"); i++ {
+				text := strings.TrimPrefix(strings.TrimLeft(lines[i], " "), ">")
+				quoted = append(quoted, strings.TrimPrefix(text, " "))
+			}
+			i--
+			out = append(out, quote(quoted, width)...)
+
+		case headingPattern.MatchString(line):
+			match := headingPattern.FindStringSubmatch(line)
+			out = append(out, heading(len(match[1]), inline(match[2])))
+
+		case rulePattern.MatchString(line):
+			// Nested quotes can leave no room at all.
+			ruleWidth := width
+			if ruleWidth < 0 {
+				ruleWidth = 0
+			}
+			out = append(out, gray+strings.Repeat("─", ruleWidth)+colorOff)
+
+		case listPattern.MatchString(line):
+			out = append(out, listItem(listPattern.FindStringSubmatch(line), width)...)
+
+		default:
+			out = append(out, wrap(inline(line), width, "", "")...)
+		}
+	}
+	return out
+}
+
+func heading(level int, text string) string {
+	switch level {
+	case 1:
+		return bold + underline + magenta + text + colorOff + underOff + boldOff
+	case 2:
+		return bold + cyan + text + colorOff + boldOff
+	case 3:
+		return bold + yellow + text + colorOff + boldOff
+	}
+	return bold + text + boldOff
+}
+
+func listItem(match []string, width int) []string {
+	indent := strings.ReplaceAll(match[1], "\t", "  ")
+	marker := match[2] + " "
+	if marker == "- " || marker == "* " || marker == "+ " {
+		marker = "• "
+	}
+	text := inline(match[4])
+	switch match[3] {
+	case "":
+	case " ":
+		marker = strings.TrimPrefix(marker, "• ") + "☐ "
+	case "x", "X":
+		marker = strings.TrimPrefix(marker, "• ") + green + "☑" + colorOff + " "
+		text = dim + strike + text + strikeOff + dimOff
+	default:
+		marker = strings.TrimPrefix(marker, "• ") + "[" + match[3] + "] "
+	}
+	prefix := indent + marker
+	return wrap(text, width, prefix, strings.Repeat(" ", visibleWidth(prefix)))
+}
+
+// quote renders a blockquote, or a callout when it starts with [!type].
+// The quoted lines are rendered as Markdown of their own.
+func quote(lines []string, width int) []string {
+	bar, title := gray+"│"+colorOff+" ", ""
+	if match := calloutPattern.FindStringSubmatch(lines[0]); match != nil {
+		kind := strings.ToLower(match[1])
+		color, ok := calloutColors[kind]
+		if !ok {
+			color = blue
+		}
+		bar = color + "┃" + colorOff + " "
+		title = match[2]
+		if title == "" {
+			title = strings.ToUpper(kind[:1]) + kind[1:]
+		}
+		title = bar + bold + color + inline(title) + colorOff + boldOff
+		lines = lines[1:]
+	}
+
+	var out []string
+	if title != "" {
+		out = append(out, title)
+	}
+	for _, line := range renderLines(lines, width-2) {
+		out = append(out, bar+line)
+	}
+	return out
+}
+
+// codeBox draws a code block in a box, cutting lines that do not fit.
+func codeBox(language string, code []string, width int) []string {
+	inner := 0
+	for i, line := range code {
+		code[i] = strings.ReplaceAll(line, "\t", "    ")
+		if w := runewidth.StringWidth(code[i]); w > inner {
+			inner = w
+		}
+	}
+	if inner < runewidth.StringWidth(language)+2 {
+		inner = runewidth.StringWidth(language) + 2
+	}
+	if inner > width-4 {
+		inner = width - 4
+	}
+	if inner < 1 {
+		inner = 1
+	}
+
+	// The language is shortened, or left out, when the box is too narrow.
+	if runewidth.StringWidth(language) > inner-1 {
+		if inner-1 >= 2 {
+			language = runewidth.Truncate(language, inner-1, "…")
+		} else {
+			language = ""
+		}
+	}
+	top := "┌" + strings.Repeat("─", inner+2) + "┐"
+	if language != "" {
+		top = "┌─ " + language + " " + strings.Repeat("─", inner-runewidth.StringWidth(language)-1) + "┐"
+	}
+	out := []string{gray + top + colorOff}
+	for _, line := range code {
+		line = runewidth.FillRight(runewidth.Truncate(line, inner, "…"), inner)
+		out = append(out, gray+"│"+colorOff+" "+line+" "+gray+"│"+colorOff)
+	}
+	out = append(out, gray+"└"+strings.Repeat("─", inner+2)+"┘"+colorOff)
+	return out
+}
+
+// table draws a Markdown table with box characters, keeping the alignment
+// given in the separator row.
+func table(rows []string) []string {
+	var cells [][]string
+	for i, row := range rows {
+		if i == 1 {
+			continue
+		}
+		var rendered []string
+		for _, cell := range splitRow(row) {
+			rendered = append(rendered, inline(cell))
+		}
+		cells = append(cells, rendered)
+	}
+	aligns := splitRow(rows[1])
+
+	widths := make([]int, len(aligns))
+	for _, row := range cells {
+		for j, cell := range row {
+			if j < len(widths) && visibleWidth(cell) > widths[j] {
+				widths[j] = visibleWidth(cell)
+			}
+		}
+	}
+
+	border := func(left, middle, right string) string {
+		parts := make([]string, len(widths))
+		for j, w := range widths {
+			parts[j] = strings.Repeat("─", w+2)
+		}
+		return gray + left + strings.Join(parts, middle) + right + colorOff
+	}
+	line := func(row []string) string {
+		parts := make([]string, len(widths))
+		for j, w := range widths {
+			cell := ""
+			if j < len(row) {
+				cell = row[j]
+			}
+			parts[j] = " " + align(cell, w, aligns[j]) + " "
+		}
+		bar := gray + "│" + colorOff
+		return bar + strings.Join(parts, bar) + bar
+	}
+
+	out := []string{border("┌", "┬", "┐")}
+	for i, row := range cells {
+		if i == 0 {
+			out = append(out, line(row), border("├", "┼", "┤"))
+			continue
+		}
+		out = append(out, line(row))
+	}
+	return append(out, border("└", "┴", "┘"))
+}
+
+func splitRow(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(row); i++ {
+		switch {
+		case row[i] == '\\' && i+1 < len(row) && row[i+1] == '|':
+			// Escaped pipes, as in [[note\|alias]], stay in the cell.
+			cell.WriteByte('|')
+			i++
+		case row[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(row[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+func align(text string, width int, separator string) string {
+	padding := width - visibleWidth(text)
+	switch {
+	case strings.HasPrefix(separator, ":") && strings.HasSuffix(separator, ":"):
+		return strings.Repeat(" ", padding/2) + text + strings.Repeat(" ", padding-padding/2)
+	case strings.HasSuffix(separator, ":"):
+		return strings.Repeat(" ", padding) + text
+	}
+	return text + strings.Repeat(" ", padding)
+}
+
+// inline styles emphasis, links and tags, leaving code spans as they are
+// apart from their color.
+func inline(text string) string {
+	var b strings.Builder
+	for len(text) > 0 {
+		start := strings.Index(text, "`")
+		if start == -1 {
+			b.WriteString(inlineText(text))
+			break
+		}
+		run := len(text[start:]) - len(strings.TrimLeft(text[start:], "`"))
+		end := strings.Index(text[start+run:], text[start:start+run])
+		if end == -1 {
+			b.WriteString(inlineText(text))
+			break
+		}
+		b.WriteString(inlineText(text[:start]))
+		b.WriteString(cyan + text[start+run:start+run+end] + colorOff)
+		text = text[start+run+end+run:]
+	}
+	return b.String()
+}
+
+func inlineText(text string) string {
+	text = imagePattern.ReplaceAllString(text, dim+"[image: $1]"+dimOff)
+	text = linkPattern.ReplaceAllString(text, underline+blue+"$1"+colorOff+underOff)
+	text = obsidian.ReplaceWikiLinks(text, func(link obsidian.WikiLink, original string) string {
+		if link.Embed {
+			return dim + "↳ " + linkText(link) + dimOff
+		}
+		return underline + blue + linkText(link) + colorOff + underOff
+	})
+	text = boldPattern.ReplaceAllString(text, bold+"$1$2"+boldOff)
+	text = italicPattern.ReplaceAllString(text, italic+"$1$2"+italicOff)
+	text = strikePattern.ReplaceAllString(text, strike+"$1"+strikeOff)
+	text = highlightPattern.ReplaceAllString(text, reverse+"$1"+reverseOff)
+	return tagPattern.ReplaceAllString(text, "$1"+cyan+"$2"+colorOff)
+}
+
+// linkText is what Obsidian shows for a link: its alias, or the note name
+// followed by the heading it points to.
+func linkText(link obsidian.WikiLink) string {
+	if link.Alias != "" {
+		return link.Alias
+	}
+	text := link.Target
+	if link.Subpath != "" {
+		if text != "" {
+			text += " > "
+		}
+		text += strings.ReplaceAll(link.Subpath, "#", " > ")
+	}
+	return text
+}
+
+// wrap breaks text into lines of at most width columns, starting the first
+// line with prefix and the others with indent. Words longer than a line are
+// left whole.
+func wrap(text string, width int, prefix string, indent string) []string {
+	available := width - visibleWidth(prefix)
+	if text == "" || available <= 0 || visibleWidth(text) <= available {
+		return []string{prefix + text}
+	}
+
+	var lines []string
+	current, currentWidth := "", 0
+	for _, word := range strings.Split(text, " ") {
+		wordWidth := visibleWidth(word)
+		if currentWidth > 0 && currentWidth+1+wordWidth > available {
+			lines = append(lines, current)
+			current, currentWidth = "", 0
+		}
+		if currentWidth > 0 {
+			current += " "
+			currentWidth++
+		}
+		current += word
+		currentWidth += wordWidth
+	}
+	lines = append(lines, current)
+
+	for i := range lines {
+		if i == 0 {
+			lines[i] = prefix + lines[i]
+		} else {
+			lines[i] = indent + lines[i]
+		}
+	}
+	return lines
+}
+
+func visibleWidth(text string) int {
+	return runewidth.StringWidth(ansiPattern.ReplaceAllString(text, ""))
+}
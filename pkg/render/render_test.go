@@ -0,0 +1,82 @@
+package render_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Yakitrak/obsidian-cli/pkg/render"
+	"github.com/stretchr/testify/assert"
+)
+
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// plain renders content and removes the styles, to check the layout.
+func plain(content string, width int) string {
+	return ansiPattern.ReplaceAllString(render.Markdown(content, width), "")
+}
+
+func TestMarkdown(t *testing.T) {
+	t.Run("Styles headings and emphasis", func(t *testing.T) {
+		// Act
+		rendered := render.Markdown("# Title\n**bold** and *italic*", 80)
+		// Assert
+		assert.Equal(t, "\x1b[1m\x1b[4m\x1b[35mTitle\x1b[39m\x1b[24m\x1b[22m\n\x1b[1mbold\x1b[22m and \x1b[3mitalic\x1b[23m", rendered)
+	})
+
+	t.Run("Leaves out frontmatter and shows links as their text", func(t *testing.T) {
+		rendered := plain("---\ntags: [a]\n---\nSee [[Note|the note]], [[Other#Setup]] and [site](https://example.com)", 80)
+		assert.Equal(t, "See the note, Other > Setup and site", rendered)
+	})
+
+	t.Run("Lists and checkboxes", func(t *testing.T) {
+		rendered := plain("- item\n\t- [ ] todo\n1. [x] done", 80)
+		assert.Equal(t, "• item\n  ☐ todo\n1. ☑ done", rendered)
+	})
+
+	t.Run("Wraps long lines to the width", func(t *testing.T) {
+		rendered := plain("- one two three four five", 12)
+		assert.Equal(t, "• one two\n  three four\n  five", rendered)
+	})
+
+	t.Run("Callouts and quotes", func(t *testing.T) {
+		rendered := plain("> [!tip]\n> Use **this**\n\n> quoted", 80)
+		assert.Equal(t, "┃ Tip\n┃ Use this\n\n│ quoted", rendered)
+	})
+
+	t.Run("Code blocks are boxed and not styled", func(t *testing.T) {
+		rendered := plain("```go\nx := *a*\n```", 80)
+		assert.Equal(t, strings.Join([]string{
+			"┌─ go ─────┐",
+			"│ x := *a* │",
+			"└──────────┘",
+		}, "\n"), rendered)
+	})
+
+	t.Run("Code block languages are shortened to fit", func(t *testing.T) {
+		rendered := plain("```javascript\nx\n```", 10)
+		assert.Equal(t, strings.Join([]string{
+			"┌─ java… ┐",
+			"│ x      │",
+			"└────────┘",
+		}, "\n"), rendered)
+	})
+
+	t.Run("Narrow widths do not break code blocks and rules", func(t *testing.T) {
+		assert.NotPanics(t, func() { render.Markdown("```javascript\nx\n```\n", 4) })
+		assert.NotPanics(t, func() { render.Markdown("```javascript\nx\n```\n", 0) })
+		assert.NotPanics(t, func() { render.Markdown("> ---\n", 1) })
+		assert.NotPanics(t, func() { render.Markdown("> > > ---\n", 3) })
+	})
+
+	t.Run("Tables keep their alignment", func(t *testing.T) {
+		rendered := plain("| name | n |\n|:-----|--:|\n| [[a\\|b]] | 10 |", 80)
+		assert.Equal(t, strings.Join([]string{
+			"┌──────┬────┐",
+			"│ name │  n │",
+			"├──────┼────┤",
+			"│ b    │ 10 │",
+			"└──────┴────┘",
+		}, "\n"), rendered)
+	})
+}
@@ -0,0 +1,21 @@
+package render
+
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
+// TerminalWidth returns the width of the terminal f writes to, and false
+// when f is not a terminal, e.g. when output is piped to another command.
+func TerminalWidth(f *os.File) (int, bool) {
+	fd := int(f.Fd())
+	if !term.IsTerminal(fd) {
+		return 0, false
+	}
+	width, _, err := term.GetSize(fd)
+	if err != nil || width <= 0 {
+		return DefaultWidth, true
+	}
+	return width, true
+}